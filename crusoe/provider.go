@@ -84,9 +84,14 @@ func (p *crusoeProvider) DataSources(_ context.Context) []func() datasource.Data
 		vm.NewVMDataSource,
 		disk.NewDisksDataSource,
 		ib_network.NewIBNetworkDataSource,
+		ib_network.NewIBNetworkSelectionDataSource,
+		ib_partition.NewIBPartitionsDataSource,
 		project.NewProjectsDataSource,
 		vpc_network.NewVPCNetworksDataSource,
+		vpc_network.NewVPCNetworkDataSource,
+		firewall_rule.NewFirewallRulesDataSource,
 		vpc_subnet.NewVPCSubnetsDataSource,
+		vpc_subnet.NewVPCSubnetDataSource,
 		instance_template.NewInstanceTemplatesDataSource,
 		instance_group.NewInstanceGroupsDataSource,
 		load_balancer.NewLoadBalancerDataSource,
@@ -110,10 +115,12 @@ func (p *crusoeProvider) Resources(_ context.Context) []func() resource.Resource
 		vm.NewVMByTemplateResource,
 		disk.NewDiskResource,
 		firewall_rule.NewFirewallRuleResource,
+		firewall_rule.NewFirewallPolicyResource,
 		ib_partition.NewIBPartitionResource,
 		project.NewProjectResource,
 		vpc_network.NewVPCNetworkResource,
 		vpc_subnet.NewVPCSubnetResource,
+		vpc_subnet.NewVPCNATGatewayResource,
 		instance_template.NewInstanceTemplateResource,
 		instance_group.NewInstanceGroupResource,
 		load_balancer.NewLoadBalancerResource,
@@ -0,0 +1,10 @@
+data "crusoe_ib_network_selection" "example" {
+  location   = "us-east1-a"
+  slice_type = "h100-80gb-sxm-ib.8x"
+  count      = 2
+}
+
+resource "crusoe_ib_partition" "example" {
+  name          = "my-ib-partition"
+  ib_network_id = data.crusoe_ib_network_selection.example.id
+}
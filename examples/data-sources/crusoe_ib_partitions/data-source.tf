@@ -0,0 +1,5 @@
+data "crusoe_ib_networks" "example" {}
+
+data "crusoe_ib_partitions" "example" {
+  ib_network_id = data.crusoe_ib_networks.example.ib_networks[0].id
+}
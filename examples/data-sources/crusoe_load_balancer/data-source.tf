@@ -1 +1,10 @@
 data "crusoe_load_balancer" "example" {}
+
+# Look up a single load balancer by name and use its public addresses.
+data "crusoe_load_balancer" "web" {
+  name = "my-load-balancer"
+}
+
+output "web_public_ips" {
+  value = data.crusoe_load_balancer.web.load_balancers[0].public_ipv4_addresses
+}
@@ -0,0 +1,4 @@
+data "crusoe_vpc_firewall_rules" "example" {
+  direction = "ingress"
+  port      = 22
+}
@@ -0,0 +1,7 @@
+data "crusoe_vpc_network" "example" {
+  name = "my-vpc-network"
+}
+
+output "vpc_network_cidr" {
+  value = data.crusoe_vpc_network.example.cidr
+}
@@ -0,0 +1,18 @@
+data "crusoe_vpc_subnet" "example" {
+  name     = "my-vpc-subnet"
+  location = "us-east1-a"
+}
+
+resource "crusoe_compute_instance" "example" {
+  name     = "my-vm"
+  type     = "a100-80gb.1x"
+  image    = "ubuntu22.04:latest"
+  location = data.crusoe_vpc_subnet.example.location
+  ssh_key  = file("~/.ssh/id_ed25519.pub")
+
+  network_interfaces = [
+    {
+      subnet = data.crusoe_vpc_subnet.example.id
+    }
+  ]
+}
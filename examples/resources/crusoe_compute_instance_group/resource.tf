@@ -23,4 +23,17 @@ resource "crusoe_compute_instance_group" "example" {
   name                 = "my-instance-group"
   instance_template_id = crusoe_instance_template.example.id
   desired_count        = 3
+
+  # Replace existing instances two at a time when the template changes, waiting for SSH on each
+  # replacement before moving on.
+  rolling_update {
+    max_unavailable = 1
+    max_surge       = 1
+    port            = 22
+  }
+
+  # Don't finish the apply until all three instances are running.
+  wait_for {
+    timeout = "30m"
+  }
 }
@@ -0,0 +1,3 @@
+# Firewall policies are imported by the ID of the VPC network they manage. To target a
+# specific project, append the project ID using the format "<network_id>,<project_id>".
+terraform import crusoe_vpc_firewall_policy.example <network_id>
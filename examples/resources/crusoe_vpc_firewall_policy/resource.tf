@@ -0,0 +1,31 @@
+resource "crusoe_vpc_network" "example" {
+  name = "my-vpc-network"
+  cidr = "10.0.0.0/8"
+}
+
+resource "crusoe_vpc_firewall_policy" "example" {
+  network = crusoe_vpc_network.example.id
+
+  rules = [
+    {
+      name              = "allow-ssh"
+      action            = "allow"
+      direction         = "ingress"
+      protocols         = "tcp"
+      source            = "0.0.0.0/0"
+      source_ports      = "1-65535"
+      destination       = crusoe_vpc_network.example.cidr
+      destination_ports = "22"
+    },
+    {
+      name              = "allow-https"
+      action            = "allow"
+      direction         = "ingress"
+      protocols         = "tcp"
+      source            = "0.0.0.0/0"
+      source_ports      = "1-65535"
+      destination       = crusoe_vpc_network.example.cidr
+      destination_ports = "443"
+    },
+  ]
+}
@@ -0,0 +1,3 @@
+# NAT gateways are imported by the ID of their subnet. To target a specific
+# project, append the project ID using the format "<vpc_subnet_id>,<project_id>".
+terraform import crusoe_vpc_nat_gateway.example <vpc_subnet_id>
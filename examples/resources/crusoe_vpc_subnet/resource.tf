@@ -9,3 +9,11 @@ resource "crusoe_vpc_subnet" "example" {
   location = "us-east1-a"
   network  = crusoe_vpc_network.example.id
 }
+
+# Let the provider pick the lowest free /24 in the network's CIDR.
+resource "crusoe_vpc_subnet" "allocated" {
+  name               = "my-allocated-subnet"
+  cidr_prefix_length = 24
+  location           = "us-east1-a"
+  network            = crusoe_vpc_network.example.id
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const deletionProtectionSummary = "Deletion Protection Enabled"
+
+// DeletionProtectionAttribute returns the deletion_protection schema attribute shared by stateful
+// resources. The flag is enforced by the provider only; it is never sent to the API.
+func DeletionProtectionAttribute(resourceName string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		MarkdownDescription: fmt.Sprintf("Whether Terraform is prevented from destroying or replacing the %s. "+
+			"Set this to `false` and apply before destroying or replacing it. Defaults to `false`.", resourceName),
+		Default: booldefault.StaticBool(false),
+	}
+}
+
+// DeletionProtectionOrDefault returns v, or false if it is null, for filling deletion_protection
+// in state where no configured value exists, such as after an import or state upgrade.
+func DeletionProtectionOrDefault(v types.Bool) types.Bool {
+	if v.IsNull() || v.IsUnknown() {
+		return types.BoolValue(false)
+	}
+
+	return v
+}
+
+// CheckDeletionProtection adds an error and returns true if deletion_protection is enabled in
+// state, in which case the resource must not be deleted. Call it at the start of Delete.
+func CheckDeletionProtection(ctx context.Context, state TFAttributeGetter, resourceName string, diags *diag.Diagnostics) bool {
+	if !deletionProtectionEnabled(ctx, state) {
+		return false
+	}
+
+	diags.AddAttributeError(path.Root("deletion_protection"), deletionProtectionSummary,
+		fmt.Sprintf("The %s cannot be destroyed while deletion_protection is true. "+
+			"Set deletion_protection to false and apply before destroying it.", resourceName))
+
+	return true
+}
+
+// ModifyPlanDeletionProtection fails the plan if it would destroy or replace a resource whose
+// deletion_protection is enabled in state. Call it at the end of the resource's ModifyPlan, once
+// attribute plan modifiers have recorded any replacement in resp.RequiresReplace.
+//
+//nolint:gocritic // hugeParam: req is passed by value to match resource.ResourceWithModifyPlan
+func ModifyPlanDeletionProtection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, resourceName string) {
+	if req.State.Raw.IsNull() || !deletionProtectionEnabled(ctx, req.State) {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		CheckDeletionProtection(ctx, req.State, resourceName, &resp.Diagnostics)
+
+		return
+	}
+
+	if len(resp.RequiresReplace) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("deletion_protection"), deletionProtectionSummary,
+			fmt.Sprintf("The %s cannot be replaced while deletion_protection is true, but changes to %s require replacement. "+
+				"Set deletion_protection to false and apply before making these changes.", resourceName, resp.RequiresReplace))
+	}
+}
+
+func deletionProtectionEnabled(ctx context.Context, state TFAttributeGetter) bool {
+	var enabled types.Bool
+	if diags := state.GetAttribute(ctx, path.Root("deletion_protection"), &enabled); diags.HasError() {
+		return false
+	}
+
+	return enabled.ValueBool()
+}
@@ -0,0 +1,88 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var deletionProtectionTestSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"name":                schema.StringAttribute{Required: true},
+		"deletion_protection": DeletionProtectionAttribute("widget"),
+	},
+}
+
+// deletionProtectionTestValue builds a raw value for deletionProtectionTestSchema. A nil
+// protected produces a null object, as for a resource being created or destroyed.
+func deletionProtectionTestValue(ctx context.Context, protected *bool) tftypes.Value {
+	objType := deletionProtectionTestSchema.Type().TerraformType(ctx)
+	if protected == nil {
+		return tftypes.NewValue(objType, nil)
+	}
+
+	return tftypes.NewValue(objType, map[string]tftypes.Value{
+		"name":                tftypes.NewValue(tftypes.String, "widget-1"),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, *protected),
+	})
+}
+
+func TestModifyPlanDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name            string
+		state           *bool
+		plan            *bool
+		requiresReplace bool
+		wantErr         bool
+	}{
+		{name: "create", state: nil, plan: &enabled, wantErr: false},
+		{name: "destroy protected", state: &enabled, plan: nil, wantErr: true},
+		{name: "destroy unprotected", state: &disabled, plan: nil, wantErr: false},
+		{name: "replace protected", state: &enabled, plan: &enabled, requiresReplace: true, wantErr: true},
+		{name: "replace unprotected", state: &disabled, plan: &disabled, requiresReplace: true, wantErr: false},
+		{name: "update protected", state: &enabled, plan: &enabled, wantErr: false},
+		{name: "disable protection", state: &enabled, plan: &disabled, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: deletionProtectionTestSchema, Raw: deletionProtectionTestValue(ctx, tt.state)},
+				Plan:  tfsdk.Plan{Schema: deletionProtectionTestSchema, Raw: deletionProtectionTestValue(ctx, tt.plan)},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+			if tt.requiresReplace {
+				resp.RequiresReplace = path.Paths{path.Root("name")}
+			}
+
+			ModifyPlanDeletionProtection(ctx, req, resp, "widget")
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+				t.Errorf("ModifyPlanDeletionProtection() error = %v, want %v: %v", got, tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	enabled, disabled := true, false
+
+	for _, protected := range []bool{enabled, disabled} {
+		state := tfsdk.State{Schema: deletionProtectionTestSchema, Raw: deletionProtectionTestValue(ctx, &protected)}
+
+		var diags diag.Diagnostics
+		if got := CheckDeletionProtection(ctx, state, "widget", &diags); got != protected || diags.HasError() != protected {
+			t.Errorf("CheckDeletionProtection(%v) = %v with diagnostics %v", protected, got, diags)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/ssh"
+)
+
+// JoinSSHKeys merges the legacy single ssh_key with the ssh_keys list into the
+// newline-separated authorized_keys form accepted by the API. Keys are kept in
+// order, with ssh_key first, and any key whose fingerprint has already been seen
+// is dropped, so the same key with a different comment is only sent once.
+func JoinSSHKeys(sshKey string, sshKeys []string) (string, error) {
+	candidates := make([]string, 0, len(sshKeys)+1)
+	if sshKey != "" {
+		candidates = append(candidates, sshKey)
+	}
+	candidates = append(candidates, sshKeys...)
+
+	seen := make(map[string]struct{}, len(candidates))
+	joined := make([]string, 0, len(candidates))
+	for _, key := range candidates {
+		fingerprint, err := SSHKeyFingerprint(key)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := seen[fingerprint]; ok {
+			continue
+		}
+		seen[fingerprint] = struct{}{}
+		joined = append(joined, strings.TrimSpace(key))
+	}
+
+	return strings.Join(joined, "\n"), nil
+}
+
+// JoinTFSSHKeys is JoinSSHKeys for the ssh_key and ssh_keys attribute values of a resource model.
+func JoinTFSSHKeys(sshKey types.String, sshKeys types.List) (string, error) {
+	keys, err := TFListToStringSlice(sshKeys)
+	if err != nil {
+		return "", err
+	}
+
+	return JoinSSHKeys(sshKey.ValueString(), keys)
+}
+
+// SSHKeyFingerprint returns the SHA256 fingerprint of an authorized_keys formatted public key.
+func SSHKeyFingerprint(key string) (string, error) {
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse SSH public key: %w", err)
+	}
+
+	return ssh.FingerprintSHA256(pubKey), nil
+}
+
+// TFAttributeGetter is implemented by tfsdk.State, tfsdk.Plan, and tfsdk.Config.
+type TFAttributeGetter interface {
+	GetAttribute(ctx context.Context, p path.Path, target interface{}) diag.Diagnostics
+}
+
+// SSHKeysChanged reports whether the set of keys granted by the ssh_key and ssh_keys attributes
+// differs between state and plan. Moving a key between the two attributes, reordering ssh_keys or
+// changing a key's comment is not a change. Unknown or unparseable values are treated as a change.
+func SSHKeysChanged(ctx context.Context, state, plan TFAttributeGetter) bool {
+	stateFingerprints, ok := sshKeyFingerprints(ctx, state)
+	if !ok {
+		return true
+	}
+
+	planFingerprints, ok := sshKeyFingerprints(ctx, plan)
+	if !ok {
+		return true
+	}
+
+	return !maps.Equal(stateFingerprints, planFingerprints)
+}
+
+// SSHKeyRequiresReplaceIf is a stringplanmodifier.RequiresReplaceIfFunc for the ssh_key attribute
+// which only replaces the resource when the granted keys change.
+//
+//nolint:gocritic // hugeParam: req signature required by stringplanmodifier.RequiresReplaceIfFunc
+func SSHKeyRequiresReplaceIf(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.RequiresReplace = SSHKeysChanged(ctx, req.State, req.Plan)
+}
+
+// SSHKeysRequiresReplaceIf is a listplanmodifier.RequiresReplaceIfFunc for the ssh_keys attribute
+// which only replaces the resource when the granted keys change.
+//
+//nolint:gocritic // hugeParam: req signature required by listplanmodifier.RequiresReplaceIfFunc
+func SSHKeysRequiresReplaceIf(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.RequiresReplace = SSHKeysChanged(ctx, req.State, req.Plan)
+}
+
+func sshKeyFingerprints(ctx context.Context, src TFAttributeGetter) (fingerprints map[string]struct{}, ok bool) {
+	var sshKey types.String
+	if diags := src.GetAttribute(ctx, path.Root("ssh_key"), &sshKey); diags.HasError() || sshKey.IsUnknown() {
+		return nil, false
+	}
+
+	var sshKeys types.List
+	if diags := src.GetAttribute(ctx, path.Root("ssh_keys"), &sshKeys); diags.HasError() || sshKeys.IsUnknown() {
+		return nil, false
+	}
+
+	keys, err := TFListToStringSlice(sshKeys)
+	if err != nil {
+		return nil, false
+	}
+	if !sshKey.IsNull() {
+		keys = append(keys, sshKey.ValueString())
+	}
+
+	fingerprints = make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		fingerprint, err := SSHKeyFingerprint(key)
+		if err != nil {
+			return nil, false
+		}
+		fingerprints[fingerprint] = struct{}{}
+	}
+
+	return fingerprints, true
+}
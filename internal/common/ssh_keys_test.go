@@ -0,0 +1,177 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	testSSHKeyAlice = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBpQKowLbfxSxKbZInCBdW9+DqRgIiCwWPqskKeq54rg alice@host"
+	testSSHKeyBob   = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC/GkpDHbquCP7iPYcVAZUmCFNbD/irao4xwOLUkPBZ2 bob@host"
+)
+
+func TestJoinSSHKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		sshKey  string
+		sshKeys []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "single key only",
+			sshKey: testSSHKeyAlice,
+			want:   testSSHKeyAlice,
+		},
+		{
+			name:    "list only",
+			sshKeys: []string{testSSHKeyAlice, testSSHKeyBob},
+			want:    testSSHKeyAlice + "\n" + testSSHKeyBob,
+		},
+		{
+			name:    "single key is placed first",
+			sshKey:  testSSHKeyBob,
+			sshKeys: []string{testSSHKeyAlice},
+			want:    testSSHKeyBob + "\n" + testSSHKeyAlice,
+		},
+		{
+			name:    "duplicate across attributes is dropped",
+			sshKey:  testSSHKeyAlice,
+			sshKeys: []string{testSSHKeyAlice, testSSHKeyBob},
+			want:    testSSHKeyAlice + "\n" + testSSHKeyBob,
+		},
+		{
+			name:    "same key with a different comment is dropped",
+			sshKeys: []string{testSSHKeyAlice, "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBpQKowLbfxSxKbZInCBdW9+DqRgIiCwWPqskKeq54rg laptop"},
+			want:    testSSHKeyAlice,
+		},
+		{
+			name:    "trailing newline is trimmed",
+			sshKeys: []string{testSSHKeyAlice + "\n"},
+			want:    testSSHKeyAlice,
+		},
+		{
+			name: "no keys",
+			want: "",
+		},
+		{
+			name:    "invalid key",
+			sshKeys: []string{"not-a-key"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JoinSSHKeys(tt.sshKey, tt.sshKeys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("JoinSSHKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("JoinSSHKeys() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// sshKeysTestState builds a state holding only the ssh_key and ssh_keys attributes.
+// A nil sshKey or sshKeys produces a null value.
+func sshKeysTestState(ctx context.Context, sshKey *string, sshKeys []string) tfsdk.State {
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"ssh_key":  schema.StringAttribute{Optional: true},
+			"ssh_keys": schema.ListAttribute{Optional: true, ElementType: types.StringType},
+		},
+	}
+
+	var keyVal tftypes.Value
+	if sshKey == nil {
+		keyVal = tftypes.NewValue(tftypes.String, nil)
+	} else {
+		keyVal = tftypes.NewValue(tftypes.String, *sshKey)
+	}
+
+	listType := tftypes.List{ElementType: tftypes.String}
+	var keysVal tftypes.Value
+	if sshKeys == nil {
+		keysVal = tftypes.NewValue(listType, nil)
+	} else {
+		elems := make([]tftypes.Value, 0, len(sshKeys))
+		for _, k := range sshKeys {
+			elems = append(elems, tftypes.NewValue(tftypes.String, k))
+		}
+		keysVal = tftypes.NewValue(listType, elems)
+	}
+
+	return tfsdk.State{
+		Schema: s,
+		Raw: tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"ssh_key":  keyVal,
+			"ssh_keys": keysVal,
+		}),
+	}
+}
+
+func TestSSHKeysChanged(t *testing.T) {
+	ctx := context.Background()
+	alice := testSSHKeyAlice
+	aliceLaptop := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBpQKowLbfxSxKbZInCBdW9+DqRgIiCwWPqskKeq54rg laptop"
+	bob := testSSHKeyBob
+
+	tests := []struct {
+		name  string
+		state tfsdk.State
+		plan  tfsdk.State
+		want  bool
+	}{
+		{
+			name:  "unchanged single key",
+			state: sshKeysTestState(ctx, &alice, nil),
+			plan:  sshKeysTestState(ctx, &alice, nil),
+			want:  false,
+		},
+		{
+			name:  "moving ssh_key into ssh_keys is not a change",
+			state: sshKeysTestState(ctx, &alice, nil),
+			plan:  sshKeysTestState(ctx, nil, []string{alice}),
+			want:  false,
+		},
+		{
+			name:  "reordering ssh_keys is not a change",
+			state: sshKeysTestState(ctx, nil, []string{alice, bob}),
+			plan:  sshKeysTestState(ctx, nil, []string{bob, alice}),
+			want:  false,
+		},
+		{
+			name:  "changing a comment is not a change",
+			state: sshKeysTestState(ctx, &alice, nil),
+			plan:  sshKeysTestState(ctx, &aliceLaptop, nil),
+			want:  false,
+		},
+		{
+			name:  "adding a key is a change",
+			state: sshKeysTestState(ctx, &alice, nil),
+			plan:  sshKeysTestState(ctx, &alice, []string{bob}),
+			want:  true,
+		},
+		{
+			name:  "removing a key is a change",
+			state: sshKeysTestState(ctx, nil, []string{alice, bob}),
+			plan:  sshKeysTestState(ctx, nil, []string{alice}),
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SSHKeysChanged(ctx, tt.state, tt.plan); got != tt.want {
+				t.Errorf("SSHKeysChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -471,6 +471,31 @@ func SortByKeys[T any](items []T, keyFns ...func(T) string) {
 	})
 }
 
+// SelectSingle returns the one item for which match is true, for data sources which look up a
+// single resource. kind and criteria are used in the error when zero or several items match, for
+// example "VPC network" and `name "prod-vpc"`.
+func SelectSingle[T any](items []T, match func(*T) bool, kind, criteria string) (*T, error) {
+	var found *T
+	matches := 0
+	for i := range items {
+		if match(&items[i]) {
+			if found == nil {
+				found = &items[i]
+			}
+			matches++
+		}
+	}
+
+	switch matches {
+	case 0:
+		return nil, fmt.Errorf("no %s matches %s", kind, criteria)
+	case 1:
+		return found, nil
+	default:
+		return nil, fmt.Errorf("%d %ss match %s; add more criteria so that only one matches", matches, kind, criteria)
+	}
+}
+
 func StringerMapToTFMap[T fmt.Stringer](m map[string]T) (types.Map, diag.Diagnostics) {
 	tfMap := make(map[string]attr.Value)
 	for key, val := range m {
@@ -3,6 +3,7 @@ package common
 import (
 	"net/http"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -289,3 +290,21 @@ func TestSortByKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectSingle(t *testing.T) {
+	items := []string{"prod", "dev", "prod"}
+
+	got, err := SelectSingle(items, func(s *string) bool { return *s == "dev" }, "network", `name "dev"`)
+	if err != nil || got != &items[1] {
+		t.Errorf("SelectSingle(dev) = %v, %v, want the second item", got, err)
+	}
+
+	if _, err := SelectSingle(items, func(s *string) bool { return *s == "test" }, "network", `name "test"`); err == nil {
+		t.Error("SelectSingle(test) returned no error for zero matches")
+	}
+
+	_, err = SelectSingle(items, func(s *string) bool { return *s == "prod" }, "network", `name "prod"`)
+	if err == nil || !strings.Contains(err.Error(), "2 networks match") {
+		t.Errorf("SelectSingle(prod) error = %v, want a multiple-match error", err)
+	}
+}
@@ -0,0 +1,30 @@
+package disk
+
+import (
+	"context"
+	"fmt"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+// listDiskAttachments maps the ID of each attached disk in the project to the VMs it is attached
+// to. The disks API does not report attachments, so they are joined from the VMs' disk attachments.
+func listDiskAttachments(ctx context.Context, apiClient *swagger.APIClient, projectID string) (map[string][]swagger.InstanceV1, error) {
+	dataResp, httpResp, err := apiClient.VMsApi.ListInstances(ctx, projectID, &swagger.VMsApiListInstancesOpts{})
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VMs: %w", common.UnpackAPIError(err))
+	}
+
+	attachments := make(map[string][]swagger.InstanceV1)
+	for i := range dataResp.Items {
+		for _, disk := range dataResp.Items[i].Disks {
+			attachments[disk.Id] = append(attachments[disk.Id], dataResp.Items[i])
+		}
+	}
+
+	return attachments, nil
+}
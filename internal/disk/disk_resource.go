@@ -3,12 +3,14 @@ package disk
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -20,6 +22,7 @@ import (
 	swagger "github.com/crusoecloud/client-go/swagger/v1"
 	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
 	validators "github.com/crusoecloud/terraform-provider-crusoe/internal/validators"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/vm"
 )
 
 const (
@@ -45,6 +48,9 @@ type diskResourceModel struct {
 	BlockSize    types.Int64  `tfsdk:"block_size"`
 	DNSName      types.String `tfsdk:"dns_name"`
 	Vips         types.List   `tfsdk:"vips"`
+
+	StopAttachedInstanceForResize types.Bool `tfsdk:"stop_attached_instance_for_resize"`
+	DeletionProtection            types.Bool `tfsdk:"deletion_protection"`
 }
 
 func NewDiskResource() resource.Resource {
@@ -114,7 +120,7 @@ func (r *diskResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			},
 			"size": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: apiDescSize,
+				MarkdownDescription: apiDescSize + " " + providerDescSize,
 				Validators:          []validator.String{validators.StorageSizeValidator{}},
 			},
 			"serial_number": schema.StringAttribute{
@@ -144,6 +150,13 @@ func (r *diskResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: apiDescVips + " " + providerDescSharedVolumeEmpty,
 				PlanModifiers:       []planmodifier.List{listplanmodifier.UseStateForUnknown()},
 			},
+			"stop_attached_instance_for_resize": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: providerDescStopAttachedInstanceForResize,
+				Default:             booldefault.StaticBool(false),
+			},
+			"deletion_protection": common.DeletionProtectionAttribute("disk"),
 		},
 	}
 }
@@ -208,6 +221,8 @@ func (r *diskResource) Create(ctx context.Context, req resource.CreateRequest, r
 	state.ProjectID = types.StringValue(projectID)
 	diskToTerraformResourceModel(disk, &state, plan.Size.ValueString())
 	state.BlockSize = preserveDeprecatedBlockSize(plan.BlockSize, disk.BlockSize)
+	state.StopAttachedInstanceForResize = plan.StopAttachedInstanceForResize
+	state.DeletionProtection = plan.DeletionProtection
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
@@ -243,6 +258,11 @@ func (r *diskResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	diskToTerraformResourceModel(&disk, &state, state.Size.ValueString())
 	state.BlockSize = preserveDeprecatedBlockSize(state.BlockSize, disk.BlockSize)
+	if state.StopAttachedInstanceForResize.IsNull() {
+		// not returned by the API, so imported disks start from the default
+		state.StopAttachedInstanceForResize = types.BoolValue(false)
+	}
+	state.DeletionProtection = common.DeletionProtectionOrDefault(state.DeletionProtection)
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
@@ -258,6 +278,44 @@ func (r *diskResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// only stop_attached_instance_for_resize changed, which needs no API call
+	if plan.Size.Equal(state.Size) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+
+		return
+	}
+
+	if plan.StopAttachedInstanceForResize.ValueBool() {
+		running, err := runningAttachedInstances(ctx, r.client.APIClient, plan.ProjectID.ValueString(), plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to resize disk",
+				fmt.Sprintf("There was an error finding the VMs the disk is attached to: %s", err))
+
+			return
+		}
+
+		// restart every VM that was stopped, whether or not the resize succeeds
+		stopped := make([]string, 0, len(running))
+		defer func() {
+			for _, instanceID := range stopped {
+				if err := vm.SetInstancePowerState(ctx, r.client.APIClient, plan.ProjectID.ValueString(), instanceID, vm.ActionStart); err != nil {
+					resp.Diagnostics.AddError("Failed to start instance after disk resize",
+						fmt.Sprintf("The VM %s was stopped to resize the disk but could not be restarted: %s", instanceID, err))
+				}
+			}
+		}()
+
+		for _, instanceID := range running {
+			if err := vm.SetInstancePowerState(ctx, r.client.APIClient, plan.ProjectID.ValueString(), instanceID, vm.ActionStop); err != nil {
+				resp.Diagnostics.AddError("Failed to resize disk",
+					fmt.Sprintf("There was an error stopping the attached VM %s before resizing: %s", instanceID, err))
+
+				return
+			}
+			stopped = append(stopped, instanceID)
+		}
+	}
+
 	dataResp, httpResp, err := r.client.APIClient.DisksApi.ResizeDisk(ctx,
 		swagger.DisksPatchRequest{Size: plan.Size.ValueString()},
 		plan.ProjectID.ValueString(),
@@ -290,8 +348,71 @@ func (r *diskResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
+//nolint:gocritic // Implements Terraform defined interface
+func (r *diskResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.modifyPlanSize(ctx, req, resp)
+	common.ModifyPlanDeletionProtection(ctx, req, resp, "disk")
+}
+
+// modifyPlanSize checks resizes at plan time, so problems the resize API would reject surface
+// before apply. Plans which do not change the size are not checked.
+//
+//nolint:gocritic // hugeParam: req is passed through from ModifyPlan
+func (r *diskResource) modifyPlanSize(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan diskResourceModel
+	if err := common.GetResourceModel(ctx, req.Plan, &plan, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	if plan.Size.IsUnknown() {
+		return
+	}
+
+	var state diskResourceModel
+	if err := common.GetResourceModel(ctx, req.State, &state, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	if plan.Size.Equal(state.Size) {
+		return
+	}
+
+	if err := checkDiskResize(state.Size.ValueString(), plan.Size.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("size"), "Unsupported disk resize", err.Error())
+
+		return
+	}
+
+	if r.client == nil || plan.StopAttachedInstanceForResize.ValueBool() {
+		return
+	}
+
+	running, err := runningAttachedInstances(ctx, r.client.APIClient, state.ProjectID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("size"), "Unable to check attached VMs",
+			fmt.Sprintf("Could not check whether the disk is attached to a running VM: %s", err))
+
+		return
+	}
+
+	if len(running) > 0 {
+		resp.Diagnostics.AddAttributeWarning(path.Root("size"), "Disk is attached to a running VM",
+			fmt.Sprintf("The disk is attached to running VM(s) %s, which must be powered off before the disk can be resized. "+
+				"Stop them before applying, or set stop_attached_instance_for_resize = true to stop and restart them around the resize.",
+				strings.Join(running, ", ")))
+	}
+}
+
 //nolint:gocritic // Implements Terraform defined interface
 func (r *diskResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if common.CheckDeletionProtection(ctx, req.State, "disk", &resp.Diagnostics) {
+		return
+	}
+
 	var state diskResourceModel
 	if err := common.GetResourceModel(ctx, req.State, &state, &resp.Diagnostics); err != nil {
 		return
@@ -55,6 +55,8 @@ func (r *diskResource) UpgradeState(context.Context) map[int64]resource.StateUpg
 				var state diskResourceModel
 				state.ProjectID = types.StringValue(projectID)
 				diskToTerraformResourceModel(disk, &state, "") // no prior size format to preserve
+				state.StopAttachedInstanceForResize = types.BoolValue(false)
+				state.DeletionProtection = types.BoolValue(false)
 
 				resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 				if resp.Diagnostics.HasError() {
@@ -2,14 +2,20 @@ package disk
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 	"slices"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	swagger "github.com/crusoecloud/client-go/swagger/v1"
 	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+	validators "github.com/crusoecloud/terraform-provider-crusoe/internal/validators"
 )
 
 type disksDataSource struct {
@@ -18,6 +24,13 @@ type disksDataSource struct {
 
 type disksDataSourceModel struct {
 	ProjectID types.String `tfsdk:"project_id"`
+	Name      *string      `tfsdk:"name"`
+	NameRegex *string      `tfsdk:"name_regex"`
+	Type      *string      `tfsdk:"type"`
+	Location  *string      `tfsdk:"location"`
+	MinSize   *string      `tfsdk:"min_size"`
+	MaxSize   *string      `tfsdk:"max_size"`
+	Attached  *bool        `tfsdk:"attached"`
 	Disks     []diskModel  `tfsdk:"disks"`
 }
 
@@ -31,6 +44,8 @@ type diskModel struct {
 	BlockSize    int64    `tfsdk:"block_size"`
 	DNSName      string   `tfsdk:"dns_name"`
 	Vips         []string `tfsdk:"vips"`
+
+	AttachedInstanceIDs []string `tfsdk:"attached_instance_ids"`
 }
 
 // TODO: let's also implement a singular DiskDataSource for fetching one disk with filtering
@@ -66,6 +81,37 @@ func (ds *disksDataSource) Schema(ctx context.Context, request datasource.Schema
 			"project_id": schema.StringAttribute{
 				Optional: true,
 			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescNameFilter,
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescNameRegexFilter,
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescTypeFilter,
+				Validators:          []validator.String{stringvalidator.OneOf(persistentSSD, sharedVolume)},
+			},
+			"location": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescLocationFilter,
+			},
+			"min_size": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescMinSizeFilter,
+				Validators:          []validator.String{validators.StorageSizeValidator{}},
+			},
+			"max_size": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescMaxSizeFilter,
+				Validators:          []validator.String{validators.StorageSizeValidator{}},
+			},
+			"attached": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescAttachedFilter,
+			},
 			"disks": schema.ListNestedAttribute{
 				Computed:            true,
 				MarkdownDescription: providerDescDisks,
@@ -109,6 +155,11 @@ func (ds *disksDataSource) Schema(ctx context.Context, request datasource.Schema
 							ElementType:         types.StringType,
 							MarkdownDescription: apiDescVips + " " + providerDescSharedVolumeEmpty,
 						},
+						"attached_instance_ids": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: providerDescAttachedInstanceIDs,
+						},
 					},
 				},
 			},
@@ -125,6 +176,18 @@ func (ds *disksDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	var nameRegex *regexp.Regexp
+	if config.NameRegex != nil {
+		re, err := regexp.Compile(*config.NameRegex)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid name_regex",
+				fmt.Sprintf("name_regex is not a valid regular expression: %s", err))
+
+			return
+		}
+		nameRegex = re
+	}
+
 	projectID := common.GetProjectIDOrFallback(ds.client, config.ProjectID.ValueString())
 
 	dataResp, httpResp, err := ds.client.APIClient.DisksApi.ListDisks(ctx, projectID, &swagger.DisksApiListDisksOpts{})
@@ -137,7 +200,16 @@ func (ds *disksDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	var state disksDataSourceModel
+	attachments, err := listDiskAttachments(ctx, ds.client.APIClient, projectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Fetch Disks",
+			fmt.Sprintf("Could not fetch the VMs disks are attached to: %s", err))
+
+		return
+	}
+
+	state := config
+	state.Disks = []diskModel{}
 	for i := range dataResp.Items {
 		vips := dataResp.Items[i].Vips
 		if vips == nil {
@@ -155,9 +227,13 @@ func (ds *disksDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			BlockSize:    dataResp.Items[i].BlockSize,
 			DNSName:      dataResp.Items[i].DnsName,
 			Vips:         vips,
+
+			AttachedInstanceIDs: attachedInstanceIDs(attachments[dataResp.Items[i].Id]),
 		})
 	}
 
+	state.Disks = filterDisks(state.Disks, &config, nameRegex)
+
 	// Sort disks deterministically so repeated reads produce a stable ordering.
 	common.SortByKeys(state.Disks,
 		func(d diskModel) string { return d.Name },
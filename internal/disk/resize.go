@@ -0,0 +1,42 @@
+package disk
+
+import (
+	"context"
+	"fmt"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/vm"
+)
+
+// checkDiskResize returns an error if resizing from oldSize to newSize would shrink the disk.
+func checkDiskResize(oldSize, newSize string) error {
+	oldGiB, okOld := common.StorageSizeInGiB(oldSize)
+	newGiB, okNew := common.StorageSizeInGiB(newSize)
+	if !okOld || !okNew {
+		return nil
+	}
+
+	if newGiB < oldGiB {
+		return fmt.Errorf("disks can only be enlarged, but the size would shrink from %s to %s", oldSize, newSize)
+	}
+
+	return nil
+}
+
+// runningAttachedInstances returns the IDs of the running VMs the disk is attached to.
+func runningAttachedInstances(ctx context.Context, apiClient *swagger.APIClient, projectID, diskID string) ([]string, error) {
+	attachments, err := listDiskAttachments(ctx, apiClient, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var running []string
+	for i := range attachments[diskID] {
+		if !vm.IsInstanceStopped(&attachments[diskID][i]) {
+			running = append(running, attachments[diskID][i].Id)
+		}
+	}
+
+	return running, nil
+}
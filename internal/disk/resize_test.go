@@ -0,0 +1,27 @@
+package disk
+
+import "testing"
+
+func Test_checkDiskResize(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldSize string
+		newSize string
+		wantErr bool
+	}{
+		{name: "enlarge", oldSize: "100GiB", newSize: "200GiB"},
+		{name: "same size in another unit", oldSize: "1024GiB", newSize: "1TiB"},
+		{name: "enlarge across units", oldSize: "512GiB", newSize: "1TiB"},
+		{name: "shrink", oldSize: "1TiB", newSize: "500GiB", wantErr: true},
+		{name: "unparseable size", oldSize: "100GiB", newSize: "50GB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDiskResize(tt.oldSize, tt.newSize)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkDiskResize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
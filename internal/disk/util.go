@@ -2,6 +2,7 @@ package disk
 
 import (
 	"context"
+	"regexp"
 	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -31,6 +32,21 @@ const (
 	providerDescTypeRequired      = "This field will be required in a future release."
 	providerDescSharedVolumeEmpty = "Empty for other disk types."
 	providerDescDisks             = "List of disks in the project."
+
+	providerDescNameFilter          = "Only return disks with exactly this name."
+	providerDescNameRegexFilter     = "Only return disks whose name matches this regular expression."
+	providerDescTypeFilter          = "Only return disks of this type. Possible values: `persistent-ssd`, `shared-volume`."
+	providerDescLocationFilter      = "Only return disks in this location."
+	providerDescMinSizeFilter       = "Only return disks at least this large, for example `100GiB` or `1TiB`."
+	providerDescMaxSizeFilter       = "Only return disks at most this large, for example `100GiB` or `1TiB`."
+	providerDescAttachedFilter      = "If `true`, only return disks attached to a VM; if `false`, only return unattached disks."
+	providerDescAttachedInstanceIDs = "IDs of the VMs the disk is attached to."
+
+	providerDescSize = "A resize must enlarge the disk, which is checked at plan time. " +
+		"Size limits for each disk type are not checked by the provider, so a size the storage API does not accept fails on apply."
+
+	providerDescStopAttachedInstanceForResize = "Whether to stop any running VMs the disk is attached to before resizing it, " +
+		"and start them again afterwards. Defaults to `false`, in which case attached VMs must be powered off before resizing."
 )
 
 // blockSizeDeprecationMessage marks the deprecated block_size attribute on both
@@ -101,3 +117,59 @@ func stringsToAttrValues(s []string) []attr.Value {
 
 	return out
 }
+
+// filterDisks returns the disks matching every configured filter. Sizes are compared in GiB,
+// so filters and disk sizes may use different units.
+func filterDisks(disks []diskModel, config *disksDataSourceModel, nameRegex *regexp.Regexp) []diskModel {
+	minGiB, hasMin := sizeFilterGiB(config.MinSize)
+	maxGiB, hasMax := sizeFilterGiB(config.MaxSize)
+
+	filtered := make([]diskModel, 0, len(disks))
+	for i := range disks {
+		disk := &disks[i]
+		if config.Name != nil && disk.Name != *config.Name {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(disk.Name) {
+			continue
+		}
+		if config.Type != nil && disk.Type != *config.Type {
+			continue
+		}
+		if config.Location != nil && disk.Location != *config.Location {
+			continue
+		}
+		if config.Attached != nil && (len(disk.AttachedInstanceIDs) > 0) != *config.Attached {
+			continue
+		}
+		if hasMin || hasMax {
+			gib, ok := common.StorageSizeInGiB(disk.Size)
+			if !ok || (hasMin && gib < minGiB) || (hasMax && gib > maxGiB) {
+				continue
+			}
+		}
+
+		filtered = append(filtered, *disk)
+	}
+
+	return filtered
+}
+
+func sizeFilterGiB(size *string) (gib int, ok bool) {
+	if size == nil {
+		return 0, false
+	}
+
+	return common.StorageSizeInGiB(*size)
+}
+
+// attachedInstanceIDs returns the sorted IDs of the given VMs, never nil.
+func attachedInstanceIDs(instances []swagger.InstanceV1) []string {
+	ids := make([]string, 0, len(instances))
+	for i := range instances {
+		ids = append(ids, instances[i].Id)
+	}
+	slices.Sort(ids)
+
+	return ids
+}
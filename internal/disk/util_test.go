@@ -3,6 +3,7 @@ package disk
 import (
 	"context"
 	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -104,3 +105,42 @@ func Test_preserveDeprecatedBlockSize(t *testing.T) {
 		})
 	}
 }
+
+func Test_filterDisks(t *testing.T) {
+	disks := []diskModel{
+		{ID: "1", Name: "data-a", Location: "us-east1-a", Type: persistentSSD, Size: "100GiB", AttachedInstanceIDs: []string{"vm-1"}},
+		{ID: "2", Name: "data-b", Location: "us-east1-a", Type: persistentSSD, Size: "2TiB", AttachedInstanceIDs: []string{}},
+		{ID: "3", Name: "shared", Location: "us-northcentral1-a", Type: sharedVolume, Size: "10TiB", AttachedInstanceIDs: []string{}},
+	}
+	str := func(s string) *string { return &s }
+	boolean := func(b bool) *bool { return &b }
+
+	tests := []struct {
+		name      string
+		config    disksDataSourceModel
+		nameRegex *regexp.Regexp
+		wantIDs   []string
+	}{
+		{name: "no filters", wantIDs: []string{"1", "2", "3"}},
+		{name: "name", config: disksDataSourceModel{Name: str("shared")}, wantIDs: []string{"3"}},
+		{name: "name regex", nameRegex: regexp.MustCompile(`^data-`), wantIDs: []string{"1", "2"}},
+		{name: "type and location", config: disksDataSourceModel{Type: str(persistentSSD), Location: str("us-east1-a")}, wantIDs: []string{"1", "2"}},
+		{name: "unattached", config: disksDataSourceModel{Attached: boolean(false)}, wantIDs: []string{"2", "3"}},
+		{name: "attached", config: disksDataSourceModel{Attached: boolean(true)}, wantIDs: []string{"1"}},
+		{name: "size range across units", config: disksDataSourceModel{MinSize: str("1024GiB"), MaxSize: str("5TiB")}, wantIDs: []string{"2"}},
+		{name: "no match", config: disksDataSourceModel{Location: str("eu-iceland1-a")}, wantIDs: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterDisks(disks, &tt.config, tt.nameRegex)
+			gotIDs := make([]string, 0, len(got))
+			for _, d := range got {
+				gotIDs = append(gotIDs, d.ID)
+			}
+			if !reflect.DeepEqual(gotIDs, tt.wantIDs) {
+				t.Errorf("filterDisks() IDs = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package firewall_rule
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+const (
+	unmanagedRulesDelete = "delete"
+	unmanagedRulesWarn   = "warn"
+)
+
+var firewallPolicyRuleSchema = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":              types.StringType,
+		"action":            types.StringType,
+		"direction":         types.StringType,
+		"protocols":         types.StringType,
+		"source":            types.StringType,
+		"source_ports":      types.StringType,
+		"destination":       types.StringType,
+		"destination_ports": types.StringType,
+	},
+}
+
+// firewallPolicyDiff is the set of API calls needed to make a network's rules match a policy.
+type firewallPolicyDiff struct {
+	Create []firewallPolicyRuleModel
+	Update []firewallPolicyRuleUpdate
+	// Replace holds rules whose action or direction changed, which the API cannot update in place.
+	Replace []firewallPolicyRuleReplacement
+	// Unmanaged holds rules in the network which are not in the policy.
+	Unmanaged []swagger.VpcFirewallRule
+}
+
+type firewallPolicyRuleUpdate struct {
+	ID   string
+	Rule firewallPolicyRuleModel
+}
+
+type firewallPolicyRuleReplacement struct {
+	Existing swagger.VpcFirewallRule
+	Rule     firewallPolicyRuleModel
+}
+
+// firewallPolicyStep is one API call of a firewallPolicyDiff. Exactly one field is set.
+type firewallPolicyStep struct {
+	Update *firewallPolicyRuleUpdate
+	Create *firewallPolicyRuleModel
+	Delete *swagger.VpcFirewallRule
+}
+
+// steps orders the diff's API calls. Updates and new rules come first and unmanaged rules are
+// deleted last, so the network is not left without a rule the policy keeps. A replaced rule is
+// deleted right before its replacement is created, because the replacement has the same name.
+func (d *firewallPolicyDiff) steps(deleteUnmanaged bool) []firewallPolicyStep {
+	var steps []firewallPolicyStep
+	for i := range d.Update {
+		steps = append(steps, firewallPolicyStep{Update: &d.Update[i]})
+	}
+	for i := range d.Create {
+		steps = append(steps, firewallPolicyStep{Create: &d.Create[i]})
+	}
+	for i := range d.Replace {
+		steps = append(steps, firewallPolicyStep{Delete: &d.Replace[i].Existing}, firewallPolicyStep{Create: &d.Replace[i].Rule})
+	}
+	if deleteUnmanaged {
+		for i := range d.Unmanaged {
+			steps = append(steps, firewallPolicyStep{Delete: &d.Unmanaged[i]})
+		}
+	}
+
+	return steps
+}
+
+// diffFirewallPolicy compares the desired rules against the network's existing rules, matching
+// them by name. Lists are compared with listsSemanticallyEqual, so equivalent port and CIDR
+// spellings don't produce updates. If several existing rules share a name, the first is matched
+// and the rest are unmanaged.
+func diffFirewallPolicy(desired []firewallPolicyRuleModel, existing []swagger.VpcFirewallRule) firewallPolicyDiff {
+	byName := make(map[string]int, len(existing))
+	for i := len(existing) - 1; i >= 0; i-- {
+		byName[existing[i].Name] = i
+	}
+
+	var diff firewallPolicyDiff
+	matched := make(map[int]bool, len(desired))
+	for i := range desired {
+		rule := desired[i]
+		idx, ok := byName[rule.Name.ValueString()]
+		if !ok {
+			diff.Create = append(diff.Create, rule)
+
+			continue
+		}
+
+		matched[idx] = true
+		current := &existing[idx]
+		switch {
+		case current.Action != rule.Action.ValueString() || current.Direction != rule.Direction.ValueString():
+			diff.Replace = append(diff.Replace, firewallPolicyRuleReplacement{Existing: *current, Rule: rule})
+		case !firewallPolicyRuleMatches(&rule, current):
+			diff.Update = append(diff.Update, firewallPolicyRuleUpdate{ID: current.Id, Rule: rule})
+		}
+	}
+
+	for i := range existing {
+		if !matched[i] {
+			diff.Unmanaged = append(diff.Unmanaged, existing[i])
+		}
+	}
+
+	return diff
+}
+
+// firewallPolicyRuleMatches reports whether the updatable lists of a configured rule and an
+// existing rule describe the same traffic.
+func firewallPolicyRuleMatches(rule *firewallPolicyRuleModel, existing *swagger.VpcFirewallRule) bool {
+	return listsSemanticallyEqual(rule.Protocols.ValueString(), existing.Protocols, false) &&
+		listsSemanticallyEqual(rule.Source.ValueString(), cidrList(existing.Sources), false) &&
+		listsSemanticallyEqual(rule.SourcePorts.ValueString(), existing.SourcePorts, true) &&
+		listsSemanticallyEqual(rule.Destination.ValueString(), cidrList(existing.Destinations), false) &&
+		listsSemanticallyEqual(rule.DestinationPorts.ValueString(), existing.DestinationPorts, true)
+}
+
+// firewallPolicyRuleFromAPI converts an existing rule to its policy representation, preserving
+// the configured spelling of each list where it is semantically equal to the API's. Lists with no
+// configured value, as for unmanaged rules, use the API's spelling.
+func firewallPolicyRuleFromAPI(rule *swagger.VpcFirewallRule, configured *firewallPolicyRuleModel) firewallPolicyRuleModel {
+	format := func(configured types.String, apiElems []string, expandWildcard bool) types.String {
+		if configured.IsNull() || configured.IsUnknown() {
+			return types.StringValue(strings.Join(apiElems, ","))
+		}
+
+		return types.StringValue(preserveListFormat(configured.ValueString(), apiElems, expandWildcard))
+	}
+
+	return firewallPolicyRuleModel{
+		Name:             types.StringValue(rule.Name),
+		Action:           types.StringValue(rule.Action),
+		Direction:        types.StringValue(rule.Direction),
+		Protocols:        format(configured.Protocols, rule.Protocols, false),
+		Source:           format(configured.Source, cidrList(rule.Sources), false),
+		SourcePorts:      format(configured.SourcePorts, rule.SourcePorts, true),
+		Destination:      format(configured.Destination, cidrList(rule.Destinations), false),
+		DestinationPorts: format(configured.DestinationPorts, rule.DestinationPorts, true),
+	}
+}
+
+// duplicateFirewallPolicyRuleNames returns the rule names which appear more than once.
+func duplicateFirewallPolicyRuleNames(rules []firewallPolicyRuleModel) []string {
+	seen := make(map[string]bool, len(rules))
+	var duplicates []string
+	for i := range rules {
+		name := rules[i].Name.ValueString()
+		if rules[i].Name.IsUnknown() {
+			continue
+		}
+		if seen[name] && !slices.Contains(duplicates, name) {
+			duplicates = append(duplicates, name)
+		}
+		seen[name] = true
+	}
+
+	return duplicates
+}
+
+func firewallRuleNames(rules []swagger.VpcFirewallRule) string {
+	names := make([]string, 0, len(rules))
+	for i := range rules {
+		names = append(names, fmt.Sprintf("%s (%s)", rules[i].Name, rules[i].Id))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// listNetworkFirewallRules returns the firewall rules belonging to the given VPC network.
+func listNetworkFirewallRules(ctx context.Context, apiClient *swagger.APIClient, projectID, networkID string) ([]swagger.VpcFirewallRule, error) {
+	dataResp, httpResp, err := apiClient.VPCFirewallRulesApi.ListVPCFirewallRules(ctx, projectID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall rules: %w", common.UnpackAPIError(err))
+	}
+
+	var rules []swagger.VpcFirewallRule
+	for i := range dataResp.Items {
+		if dataResp.Items[i].VpcNetworkId == networkID {
+			rules = append(rules, dataResp.Items[i])
+		}
+	}
+
+	return rules, nil
+}
+
+func createPolicyFirewallRule(ctx context.Context, apiClient *swagger.APIClient, projectID, networkID string, rule *firewallPolicyRuleModel,
+) (*swagger.VpcFirewallRule, error) {
+	dataResp, httpResp, err := apiClient.VPCFirewallRulesApi.CreateVPCFirewallRule(ctx, swagger.VpcFirewallRulesPostRequestV1{
+		VpcNetworkId:     networkID,
+		Name:             rule.Name.ValueString(),
+		Action:           rule.Action.ValueString(),
+		Protocols:        stringToSlice(rule.Protocols.ValueString(), ","),
+		Direction:        rule.Direction.ValueString(),
+		Sources:          toFirewallRuleObjects(stringToSlice(rule.Source.ValueString(), ",")),
+		SourcePorts:      stringToSlice(strings.ReplaceAll(rule.SourcePorts.ValueString(), "*", wildcardPortRange), ","),
+		Destinations:     toFirewallRuleObjects(stringToSlice(rule.Destination.ValueString(), ",")),
+		DestinationPorts: stringToSlice(strings.ReplaceAll(rule.DestinationPorts.ValueString(), "*", wildcardPortRange), ","),
+	}, projectID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to start creating rule %q: %w", rule.Name.ValueString(), common.UnpackAPIError(err))
+	}
+
+	created, _, err := common.AwaitOperationAndResolve[swagger.VpcFirewallRule](ctx, dataResp.Operation, projectID,
+		apiClient.VPCFirewallRuleOperationsApi.GetNetworkingVPCFirewallRulesOperation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rule %q: %w", rule.Name.ValueString(), common.UnpackAPIError(err))
+	}
+
+	return created, nil
+}
+
+func patchPolicyFirewallRule(ctx context.Context, apiClient *swagger.APIClient, projectID string, update *firewallPolicyRuleUpdate,
+) (*swagger.VpcFirewallRule, error) {
+	rule := &update.Rule
+	dataResp, httpResp, err := apiClient.VPCFirewallRulesApi.PatchVPCFirewallRule(ctx, swagger.VpcFirewallRulesPatchRequest{
+		Name:             rule.Name.ValueString(),
+		Protocols:        stringToSlice(rule.Protocols.ValueString(), ","),
+		Sources:          toFirewallRuleObjects(stringToSlice(rule.Source.ValueString(), ",")),
+		SourcePorts:      stringToSlice(strings.ReplaceAll(rule.SourcePorts.ValueString(), "*", wildcardPortRange), ","),
+		Destinations:     toFirewallRuleObjects(stringToSlice(rule.Destination.ValueString(), ",")),
+		DestinationPorts: stringToSlice(strings.ReplaceAll(rule.DestinationPorts.ValueString(), "*", wildcardPortRange), ","),
+	}, projectID, update.ID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to start updating rule %q: %w", rule.Name.ValueString(), common.UnpackAPIError(err))
+	}
+
+	patched, _, err := common.AwaitOperationAndResolve[swagger.VpcFirewallRule](ctx, dataResp.Operation, projectID,
+		apiClient.VPCFirewallRuleOperationsApi.GetNetworkingVPCFirewallRulesOperation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update rule %q: %w", rule.Name.ValueString(), common.UnpackAPIError(err))
+	}
+
+	return patched, nil
+}
+
+func deletePolicyFirewallRule(ctx context.Context, apiClient *swagger.APIClient, projectID string, rule *swagger.VpcFirewallRule) error {
+	dataResp, httpResp, err := apiClient.VPCFirewallRulesApi.DeleteVPCFirewallRule(ctx, projectID, rule.Id)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to start deleting rule %q: %w", rule.Name, common.UnpackAPIError(err))
+	}
+
+	_, err = common.AwaitOperation(ctx, dataResp.Operation, projectID, apiClient.VPCFirewallRuleOperationsApi.GetNetworkingVPCFirewallRulesOperation)
+	if err != nil {
+		return fmt.Errorf("failed to delete rule %q: %w", rule.Name, common.UnpackAPIError(err))
+	}
+
+	return nil
+}
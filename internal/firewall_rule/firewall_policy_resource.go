@@ -0,0 +1,447 @@
+package firewall_rule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+type firewallPolicyResource struct {
+	client *common.CrusoeClient
+}
+
+type firewallPolicyResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	ProjectID      types.String `tfsdk:"project_id"`
+	Network        types.String `tfsdk:"network"`
+	UnmanagedRules types.String `tfsdk:"unmanaged_rules"`
+	Rules          types.Set    `tfsdk:"rules"`
+	RuleIDs        types.Map    `tfsdk:"rule_ids"`
+}
+
+type firewallPolicyRuleModel struct {
+	Name             types.String `tfsdk:"name"`
+	Action           types.String `tfsdk:"action"`
+	Direction        types.String `tfsdk:"direction"`
+	Protocols        types.String `tfsdk:"protocols"`
+	Source           types.String `tfsdk:"source"`
+	SourcePorts      types.String `tfsdk:"source_ports"`
+	Destination      types.String `tfsdk:"destination"`
+	DestinationPorts types.String `tfsdk:"destination_ports"`
+}
+
+func NewFirewallPolicyResource() resource.Resource {
+	return &firewallPolicyResource{}
+}
+
+func (r *firewallPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*common.CrusoeClient)
+	if !ok {
+		resp.Diagnostics.AddError("Failed to initialize provider", common.ErrorMsgProviderInitFailed)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *firewallPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vpc_firewall_policy"
+}
+
+func (r *firewallPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: providerDescPolicyID,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, // maintain across updates
+			},
+			"project_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: providerDescPolicyProjectID,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"network": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: apiDescNetwork,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"unmanaged_rules": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: providerDescUnmanagedRules,
+				Default:             stringdefault.StaticString(unmanagedRulesDelete),
+				Validators:          []validator.String{stringvalidator.OneOf(unmanagedRulesDelete, unmanagedRulesWarn)},
+			},
+			"rules": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: providerDescPolicyRules,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: apiDescName,
+						},
+						"action": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: apiDescAction,
+							Validators:          []validator.String{stringvalidator.OneOf("allow", "deny")},
+						},
+						"direction": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: apiDescDirection,
+							Validators:          []validator.String{stringvalidator.OneOf("ingress", "egress")},
+						},
+						"protocols": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: apiDescProtocols,
+						},
+						"source": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: apiDescSource,
+						},
+						"source_ports": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: apiDescSourcePorts,
+						},
+						"destination": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: apiDescDestination,
+						},
+						"destination_ports": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: apiDescDestinationPorts,
+						},
+					},
+				},
+			},
+			"rule_ids": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: providerDescRuleIDs,
+			},
+		},
+	}
+}
+
+func (r *firewallPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	networkID, projectID, errMsg := common.ParseResourceIdentifiers(req, r.client, "network_id")
+	if errMsg != "" {
+		resp.Diagnostics.AddError("Failed to import Firewall Policy", errMsg)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), networkID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network"), networkID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (r *firewallPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan firewallPolicyResourceModel
+	if err := common.GetResourceModel(ctx, req.Plan, &plan, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	projectID := common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString())
+
+	r.applyPolicy(ctx, projectID, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (r *firewallPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state firewallPolicyResourceModel
+	if err := common.GetResourceModel(ctx, req.State, &state, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	projectID := common.GetProjectIDOrFallback(r.client, state.ProjectID.ValueString())
+	if state.UnmanagedRules.IsNull() {
+		// not set on import
+		state.UnmanagedRules = types.StringValue(unmanagedRulesDelete)
+	}
+
+	existing, err := listNetworkFirewallRules(ctx, r.client.APIClient, projectID, state.Network.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read firewall policy",
+			fmt.Sprintf("There was an error fetching the network's firewall rules: %s", err))
+
+		return
+	}
+
+	var managed []firewallPolicyRuleModel
+	resp.Diagnostics.Append(state.Rules.ElementsAs(ctx, &managed, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Rules removed out of band drop out of state and are recreated on the next apply. Unmanaged
+	// rules are added to state so the next plan shows their deletion, unless they are only warned
+	// about.
+	diff := diffFirewallPolicy(managed, existing)
+	rules := make([]firewallPolicyRuleModel, 0, len(existing))
+	for i := range managed {
+		if rule := findFirewallRuleByName(existing, managed[i].Name.ValueString()); rule != nil {
+			rules = append(rules, firewallPolicyRuleFromAPI(rule, &managed[i]))
+		}
+	}
+
+	common.SortByKeys(diff.Unmanaged,
+		func(rule swagger.VpcFirewallRule) string { return rule.Name },
+		func(rule swagger.VpcFirewallRule) string { return rule.Id },
+	)
+	if len(diff.Unmanaged) > 0 && state.UnmanagedRules.ValueString() == unmanagedRulesWarn {
+		resp.Diagnostics.AddWarning("Unmanaged firewall rules",
+			fmt.Sprintf("The network has firewall rules which are not in the policy: %s. "+
+				"Set unmanaged_rules to %q to delete them.", firewallRuleNames(diff.Unmanaged), unmanagedRulesDelete))
+	} else {
+		for i := range diff.Unmanaged {
+			rules = append(rules, firewallPolicyRuleFromAPI(&diff.Unmanaged[i], &firewallPolicyRuleModel{}))
+		}
+	}
+
+	r.setPolicyRules(ctx, &state, rules, existing, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = state.Network
+	state.ProjectID = types.StringValue(projectID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (r *firewallPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state firewallPolicyResourceModel
+	if err := common.GetResourceModel(ctx, req.State, &state, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	var plan firewallPolicyResourceModel
+	if err := common.GetResourceModel(ctx, req.Plan, &plan, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	r.applyPolicy(ctx, state.ProjectID.ValueString(), &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// ModifyPlan rejects duplicate rule names, which the policy could not match to existing rules,
+// and keeps rule_ids known when the rules are unchanged.
+//
+//nolint:gocritic // Implements Terraform defined interface
+func (r *firewallPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan firewallPolicyResourceModel
+	if err := common.GetResourceModel(ctx, req.Plan, &plan, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	if !plan.Rules.IsUnknown() {
+		var rules []firewallPolicyRuleModel
+		resp.Diagnostics.Append(plan.Rules.ElementsAs(ctx, &rules, true)...)
+		if duplicates := duplicateFirewallPolicyRuleNames(rules); len(duplicates) > 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("rules"), "Duplicate firewall rule names",
+				fmt.Sprintf("Rule names must be unique within a policy, but %s appear more than once.", strings.Join(duplicates, ", ")))
+
+			return
+		}
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state firewallPolicyResourceModel
+	if err := common.GetResourceModel(ctx, req.State, &state, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	if plan.Rules.Equal(state.Rules) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rule_ids"), state.RuleIDs)...)
+	}
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (r *firewallPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state firewallPolicyResourceModel
+	if err := common.GetResourceModel(ctx, req.State, &state, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	var ruleIDs map[string]string
+	resp.Diagnostics.Append(state.RuleIDs.ElementsAs(ctx, &ruleIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := listNetworkFirewallRules(ctx, r.client.APIClient, state.ProjectID.ValueString(), state.Network.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to delete firewall policy",
+			fmt.Sprintf("There was an error fetching the network's firewall rules: %s", err))
+
+		return
+	}
+
+	// Only the policy's own rules are deleted; any unmanaged rules it was warning about remain.
+	for i := range existing {
+		if ruleIDs[existing[i].Name] != existing[i].Id {
+			continue
+		}
+
+		if err := deletePolicyFirewallRule(ctx, r.client.APIClient, state.ProjectID.ValueString(), &existing[i]); err != nil {
+			resp.Diagnostics.AddError("Failed to delete firewall policy",
+				fmt.Sprintf("There was an error deleting a firewall rule: %s", err))
+
+			return
+		}
+	}
+}
+
+// applyPolicy makes the network's rules match plan, and updates plan with the resulting rules.
+func (r *firewallPolicyResource) applyPolicy(ctx context.Context, projectID string, plan *firewallPolicyResourceModel, diags *diag.Diagnostics) {
+	networkID := plan.Network.ValueString()
+
+	var desired []firewallPolicyRuleModel
+	diags.Append(plan.Rules.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	existing, err := listNetworkFirewallRules(ctx, r.client.APIClient, projectID, networkID)
+	if err != nil {
+		diags.AddError("Failed to apply firewall policy",
+			fmt.Sprintf("There was an error fetching the network's firewall rules: %s", err))
+
+		return
+	}
+
+	diff := diffFirewallPolicy(desired, existing)
+	deleteUnmanaged := plan.UnmanagedRules.ValueString() != unmanagedRulesWarn
+	if !deleteUnmanaged && len(diff.Unmanaged) > 0 {
+		diags.AddWarning("Unmanaged firewall rules",
+			fmt.Sprintf("The network has firewall rules which are not in the policy, which were left in place: %s. "+
+				"Set unmanaged_rules to %q to delete them.", firewallRuleNames(diff.Unmanaged), unmanagedRulesDelete))
+	}
+
+	// A failed step stops the apply. Rules it didn't get to, including a replaced rule whose
+	// replacement wasn't created, are created again by the next apply.
+	results := make([]swagger.VpcFirewallRule, 0, len(desired))
+	for _, step := range diff.steps(deleteUnmanaged) {
+		switch {
+		case step.Update != nil:
+			patched, err := patchPolicyFirewallRule(ctx, r.client.APIClient, projectID, step.Update)
+			if err != nil {
+				diags.AddError("Failed to apply firewall policy",
+					fmt.Sprintf("There was an error updating a firewall rule: %s", err))
+
+				return
+			}
+			results = append(results, *patched)
+		case step.Create != nil:
+			created, err := createPolicyFirewallRule(ctx, r.client.APIClient, projectID, networkID, step.Create)
+			if err != nil {
+				diags.AddError("Failed to apply firewall policy",
+					fmt.Sprintf("There was an error creating a firewall rule: %s", err))
+
+				return
+			}
+			results = append(results, *created)
+		case step.Delete != nil:
+			if err := deletePolicyFirewallRule(ctx, r.client.APIClient, projectID, step.Delete); err != nil {
+				diags.AddError("Failed to apply firewall policy",
+					fmt.Sprintf("There was an error deleting a firewall rule: %s", err))
+
+				return
+			}
+		}
+	}
+
+	// Unchanged rules keep their existing representation. Results are searched first, so they
+	// take precedence over the existing rules they replaced.
+	results = append(results, existing...)
+
+	rules := make([]firewallPolicyRuleModel, 0, len(desired))
+	for i := range desired {
+		rule := findFirewallRuleByName(results, desired[i].Name.ValueString())
+		if rule == nil {
+			diags.AddError("Failed to apply firewall policy",
+				fmt.Sprintf("The firewall rule %q could not be found after applying the policy.", desired[i].Name.ValueString()))
+
+			return
+		}
+		rules = append(rules, firewallPolicyRuleFromAPI(rule, &desired[i]))
+	}
+
+	r.setPolicyRules(ctx, plan, rules, results, diags)
+	plan.ID = plan.Network
+	plan.ProjectID = types.StringValue(projectID)
+}
+
+// setPolicyRules stores rules and their IDs, looked up by name in apiRules, in model.
+func (r *firewallPolicyResource) setPolicyRules(ctx context.Context, model *firewallPolicyResourceModel, rules []firewallPolicyRuleModel,
+	apiRules []swagger.VpcFirewallRule, diags *diag.Diagnostics,
+) {
+	ruleIDs := make(map[string]string, len(rules))
+	for i := range rules {
+		if rule := findFirewallRuleByName(apiRules, rules[i].Name.ValueString()); rule != nil {
+			ruleIDs[rule.Name] = rule.Id
+		}
+	}
+
+	rulesSet, d := types.SetValueFrom(ctx, firewallPolicyRuleSchema, rules)
+	diags.Append(d...)
+	model.Rules = rulesSet
+
+	ruleIDsMap, d := types.MapValueFrom(ctx, types.StringType, ruleIDs)
+	diags.Append(d...)
+	model.RuleIDs = ruleIDsMap
+}
+
+// findFirewallRuleByName returns the first rule with the given name, or nil.
+func findFirewallRuleByName(rules []swagger.VpcFirewallRule, name string) *swagger.VpcFirewallRule {
+	for i := range rules {
+		if rules[i].Name == name {
+			return &rules[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package firewall_rule
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+)
+
+func testPolicyRule(name, action, sourcePorts, destinationPorts string) firewallPolicyRuleModel {
+	return firewallPolicyRuleModel{
+		Name:             types.StringValue(name),
+		Action:           types.StringValue(action),
+		Direction:        types.StringValue("ingress"),
+		Protocols:        types.StringValue("tcp"),
+		Source:           types.StringValue("10.0.0.0/8, 192.168.0.0/16"),
+		SourcePorts:      types.StringValue(sourcePorts),
+		Destination:      types.StringValue("0.0.0.0/0"),
+		DestinationPorts: types.StringValue(destinationPorts),
+	}
+}
+
+func testAPIRule(id, name, action string, destinationPorts ...string) swagger.VpcFirewallRule {
+	return swagger.VpcFirewallRule{
+		Id:               id,
+		Name:             name,
+		VpcNetworkId:     "net-1",
+		Action:           action,
+		Direction:        "ingress",
+		Protocols:        []string{"tcp"},
+		Sources:          []swagger.FirewallRuleObject{{Cidr: "192.168.0.0/16"}, {Cidr: "10.0.0.0/8"}},
+		SourcePorts:      []string{wildcardPortRange},
+		Destinations:     []swagger.FirewallRuleObject{{Cidr: "0.0.0.0/0"}},
+		DestinationPorts: destinationPorts,
+	}
+}
+
+func Test_diffFirewallPolicy(t *testing.T) {
+	desired := []firewallPolicyRuleModel{
+		testPolicyRule("ssh", "allow", "*", "22"),       // unchanged, spelled differently
+		testPolicyRule("web", "allow", "*", "80,443"),   // ports changed
+		testPolicyRule("block", "deny", "*", "25"),      // action changed
+		testPolicyRule("metrics", "allow", "*", "9100"), // new
+	}
+	existing := []swagger.VpcFirewallRule{
+		testAPIRule("fw-ssh", "ssh", "allow", "22"),
+		testAPIRule("fw-web", "web", "allow", "80"),
+		testAPIRule("fw-block", "block", "allow", "25"),
+		testAPIRule("fw-legacy", "legacy", "allow", "8080"),
+		testAPIRule("fw-ssh-2", "ssh", "allow", "22"),
+	}
+
+	diff := diffFirewallPolicy(desired, existing)
+
+	var created []string
+	for i := range diff.Create {
+		created = append(created, diff.Create[i].Name.ValueString())
+	}
+	if want := []string{"metrics"}; !reflect.DeepEqual(created, want) {
+		t.Errorf("Create = %v, want %v", created, want)
+	}
+
+	if len(diff.Update) != 1 || diff.Update[0].ID != "fw-web" {
+		t.Errorf("Update = %+v, want only fw-web", diff.Update)
+	}
+
+	if len(diff.Replace) != 1 || diff.Replace[0].Existing.Id != "fw-block" || diff.Replace[0].Rule.Action.ValueString() != "deny" {
+		t.Errorf("Replace = %+v, want only fw-block replaced by the deny rule", diff.Replace)
+	}
+
+	var unmanaged []string
+	for i := range diff.Unmanaged {
+		unmanaged = append(unmanaged, diff.Unmanaged[i].Id)
+	}
+	if want := []string{"fw-legacy", "fw-ssh-2"}; !reflect.DeepEqual(unmanaged, want) {
+		t.Errorf("Unmanaged = %v, want %v", unmanaged, want)
+	}
+}
+
+func Test_firewallPolicyDiffSteps(t *testing.T) {
+	desired := []firewallPolicyRuleModel{
+		testPolicyRule("web", "allow", "*", "80,443"),
+		testPolicyRule("block", "deny", "*", "25"),
+		testPolicyRule("metrics", "allow", "*", "9100"),
+	}
+	existing := []swagger.VpcFirewallRule{
+		testAPIRule("fw-web", "web", "allow", "80"),
+		testAPIRule("fw-block", "block", "allow", "25"),
+		testAPIRule("fw-legacy", "legacy", "allow", "8080"),
+	}
+
+	stepNames := func(steps []firewallPolicyStep) []string {
+		var names []string
+		for _, step := range steps {
+			switch {
+			case step.Update != nil:
+				names = append(names, "update "+step.Update.ID)
+			case step.Create != nil:
+				names = append(names, "create "+step.Create.Name.ValueString())
+			case step.Delete != nil:
+				names = append(names, "delete "+step.Delete.Id)
+			}
+		}
+
+		return names
+	}
+
+	tests := []struct {
+		name            string
+		deleteUnmanaged bool
+		want            []string
+	}{
+		{
+			name: "keep unmanaged",
+			want: []string{"update fw-web", "create metrics", "delete fw-block", "create block"},
+		},
+		{
+			name:            "delete unmanaged",
+			deleteUnmanaged: true,
+			want:            []string{"update fw-web", "create metrics", "delete fw-block", "create block", "delete fw-legacy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := diffFirewallPolicy(desired, existing)
+			if got := stepNames(diff.steps(tt.deleteUnmanaged)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("steps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_firewallPolicyRuleFromAPI(t *testing.T) {
+	configured := testPolicyRule("ssh", "allow", "*", "22")
+	rule := testAPIRule("fw-ssh", "ssh", "allow", "22")
+
+	got := firewallPolicyRuleFromAPI(&rule, &configured)
+	if !reflect.DeepEqual(got, configured) {
+		t.Errorf("firewallPolicyRuleFromAPI() = %+v, want the configured spelling %+v", got, configured)
+	}
+
+	unmanaged := firewallPolicyRuleFromAPI(&rule, &firewallPolicyRuleModel{})
+	if got := unmanaged.Source.ValueString(); got != "192.168.0.0/16,10.0.0.0/8" {
+		t.Errorf("unmanaged source = %q, want the API spelling", got)
+	}
+}
+
+func Test_duplicateFirewallPolicyRuleNames(t *testing.T) {
+	rules := []firewallPolicyRuleModel{
+		testPolicyRule("ssh", "allow", "*", "22"),
+		testPolicyRule("web", "allow", "*", "80"),
+		testPolicyRule("ssh", "allow", "*", "2222"),
+		testPolicyRule("ssh", "allow", "*", "22222"),
+	}
+
+	if got, want := duplicateFirewallPolicyRuleNames(rules), []string{"ssh"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("duplicateFirewallPolicyRuleNames() = %v, want %v", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package firewall_rule
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -18,6 +19,13 @@ import (
 	validators "github.com/crusoecloud/terraform-provider-crusoe/internal/validators"
 )
 
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &firewallRuleResource{}
+	_ resource.ResourceWithValidateConfig = &firewallRuleResource{}
+	_ resource.ResourceWithModifyPlan     = &firewallRuleResource{}
+)
+
 type firewallRuleResource struct {
 	client *common.CrusoeClient
 }
@@ -105,7 +113,7 @@ func (r *firewallRuleResource) Schema(ctx context.Context, req resource.SchemaRe
 			},
 			"source": schema.StringAttribute{
 				Required:    true,
-				Description: apiDescSource,
+				Description: apiDescSource + " " + providerDescAddressReferences,
 				// TODO: add validator
 			},
 			"source_ports": schema.StringAttribute{
@@ -115,7 +123,7 @@ func (r *firewallRuleResource) Schema(ctx context.Context, req resource.SchemaRe
 			},
 			"destination": schema.StringAttribute{
 				Required:    true,
-				Description: apiDescDestination,
+				Description: apiDescDestination + " " + providerDescAddressReferences,
 				// TODO: add validator
 			},
 			"destination_ports": schema.StringAttribute{
@@ -127,6 +135,106 @@ func (r *firewallRuleResource) Schema(ctx context.Context, req resource.SchemaRe
 	}
 }
 
+// ValidateConfig checks addresses, ports and protocols before any API call, and warns about
+// rules which open SSH to the internet.
+//
+//nolint:gocritic // Implements Terraform defined interface
+func (r *firewallRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config firewallRuleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	known := func(v types.String) bool { return !v.IsNull() && !v.IsUnknown() }
+
+	if known(config.Protocols) {
+		validateFirewallRuleProtocols(path.Root("protocols"), config.Protocols.ValueString(), &resp.Diagnostics)
+	}
+	if known(config.Source) {
+		validateFirewallRuleAddresses(path.Root("source"), config.Source.ValueString(), &resp.Diagnostics)
+	}
+	if known(config.SourcePorts) {
+		validateFirewallRulePorts(path.Root("source_ports"), config.SourcePorts.ValueString(), &resp.Diagnostics)
+	}
+	if known(config.Destination) {
+		validateFirewallRuleAddresses(path.Root("destination"), config.Destination.ValueString(), &resp.Diagnostics)
+	}
+	if known(config.DestinationPorts) {
+		validateFirewallRulePorts(path.Root("destination_ports"), config.DestinationPorts.ValueString(), &resp.Diagnostics)
+	}
+
+	if known(config.Action) && known(config.Direction) && known(config.Protocols) && known(config.Source) && known(config.DestinationPorts) {
+		rule := firewallRuleFromResourceModel(&config)
+		if sshOpenToInternet(&rule) {
+			resp.Diagnostics.AddAttributeWarning(path.Root("source"), "SSH open to the internet",
+				"This rule allows SSH (TCP port 22) from any address. Consider restricting source to known CIDR blocks.")
+		}
+	}
+}
+
+// ModifyPlan warns when the planned rule duplicates, or is shadowed by, another rule in the same
+// network. This needs the network's existing rules, so it can't be done in ValidateConfig, and is
+// only checked for new rules and rules whose matched traffic or action changes.
+//
+//nolint:gocritic // Implements Terraform defined interface
+func (r *firewallRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan firewallRuleResourceModel
+	if err := common.GetResourceModel(ctx, req.Plan, &plan, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	planned := []types.String{plan.Network, plan.Action, plan.Direction, plan.Protocols, plan.Source, plan.SourcePorts, plan.Destination, plan.DestinationPorts}
+	for _, v := range planned {
+		if v.IsUnknown() {
+			return
+		}
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state firewallRuleResourceModel
+		if err := common.GetResourceModel(ctx, req.State, &state, &resp.Diagnostics); err != nil {
+			return
+		}
+
+		current := []types.String{state.Network, state.Action, state.Direction, state.Protocols, state.Source, state.SourcePorts, state.Destination, state.DestinationPorts}
+		if slices.EqualFunc(planned, current, func(a, b types.String) bool { return a.Equal(b) }) {
+			return
+		}
+	}
+
+	projectID := common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString())
+	existing, err := listNetworkFirewallRules(ctx, r.client.APIClient, projectID, plan.Network.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to check for overlapping firewall rules",
+			fmt.Sprintf("Could not compare this rule with the network's other rules: %s", err))
+
+		return
+	}
+
+	rule := firewallRuleFromResourceModel(&plan)
+	for i := range existing {
+		other := &existing[i]
+		if other.Id == rule.Id {
+			continue
+		}
+
+		switch {
+		case firewallRulesEquivalent(other, &rule):
+			resp.Diagnostics.AddAttributeWarning(path.Root("name"), "Duplicate firewall rule",
+				fmt.Sprintf("This rule matches the same traffic with the same action as rule %q (%s) in the same network.", other.Name, other.Id))
+		case firewallRuleCovers(other, &rule):
+			resp.Diagnostics.AddAttributeWarning(path.Root("name"), "Shadowed firewall rule",
+				fmt.Sprintf("Rule %q (%s) in the same network already matches all traffic this rule matches, with action %q, "+
+					"so this rule may have no effect.", other.Name, other.Id, other.Action))
+		}
+	}
+}
+
 func (r *firewallRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resourceID, projectID, errMsg := common.ParseResourceIdentifiers(req, r.client, "firewall_rule_id")
 	if errMsg != "" {
@@ -148,6 +256,15 @@ func (r *firewallRuleResource) Create(ctx context.Context, req resource.CreateRe
 
 	projectID := common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString())
 
+	configured := firewallRuleAddresses{Source: plan.Source.ValueString(), Destination: plan.Destination.ValueString()}
+	resolved, err := resolveFirewallRuleAddresses(ctx, r.client.APIClient, projectID, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create firewall rule",
+			fmt.Sprintf("There was an error resolving the rule's references: %s", err))
+
+		return
+	}
+
 	sourcePortsStr := strings.ReplaceAll(plan.SourcePorts.ValueString(), "*", "1-65535")
 	destPortsStr := strings.ReplaceAll(plan.DestinationPorts.ValueString(), "*", "1-65535")
 
@@ -157,9 +274,9 @@ func (r *firewallRuleResource) Create(ctx context.Context, req resource.CreateRe
 		Action:           plan.Action.ValueString(),
 		Protocols:        stringToSlice(plan.Protocols.ValueString(), ","),
 		Direction:        plan.Direction.ValueString(),
-		Sources:          toFirewallRuleObjects(stringToSlice(plan.Source.ValueString(), ",")),
+		Sources:          toFirewallRuleObjects(stringToSlice(resolved.Source, ",")),
 		SourcePorts:      stringToSlice(sourcePortsStr, ","),
-		Destinations:     toFirewallRuleObjects(stringToSlice(plan.Destination.ValueString(), ",")),
+		Destinations:     toFirewallRuleObjects(stringToSlice(resolved.Destination, ",")),
 		DestinationPorts: stringToSlice(destPortsStr, ","),
 	}, projectID)
 	if httpResp != nil {
@@ -183,6 +300,7 @@ func (r *firewallRuleResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	firewallRuleToTerraformResourceModel(firewallRule, &plan)
+	preserveFirewallRuleReferences(&plan, firewallRule, configured, resolved)
 	plan.ProjectID = types.StringValue(projectID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
@@ -211,8 +329,21 @@ func (r *firewallRuleResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	state.ProjectID = types.StringValue(projectID)
+	configured := firewallRuleAddresses{Source: state.Source.ValueString(), Destination: state.Destination.ValueString()}
 	firewallRuleToTerraformResourceModel(&rule, &state)
 
+	// Re-resolve references so a change in a referenced object's addresses shows up as drift.
+	if hasFirewallRuleReferences(configured.Source) || hasFirewallRuleReferences(configured.Destination) {
+		model := firewallRuleResourceModel{Source: types.StringValue(configured.Source), Destination: types.StringValue(configured.Destination)}
+		resolved, err := resolveFirewallRuleAddresses(ctx, r.client.APIClient, projectID, &model)
+		if err != nil {
+			resp.Diagnostics.AddWarning("Unable to resolve firewall rule references",
+				fmt.Sprintf("The rule's current addresses are shown instead: %s", err))
+		} else {
+			preserveFirewallRuleReferences(&state, &rule, configured, resolved)
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -227,6 +358,17 @@ func (r *firewallRuleResource) Update(ctx context.Context, req resource.UpdateRe
 	if err := common.GetResourceModel(ctx, req.Plan, &plan, &resp.Diagnostics); err != nil {
 		return
 	}
+
+	projectID := common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString())
+	configured := firewallRuleAddresses{Source: plan.Source.ValueString(), Destination: plan.Destination.ValueString()}
+	resolved, err := resolveFirewallRuleAddresses(ctx, r.client.APIClient, projectID, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to patch firewall rule",
+			fmt.Sprintf("There was an error resolving the rule's references: %s", err))
+
+		return
+	}
+
 	patchReq := swagger.VpcFirewallRulesPatchRequest{}
 	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
 		patchReq.Name = plan.Name.ValueString()
@@ -235,13 +377,13 @@ func (r *firewallRuleResource) Update(ctx context.Context, req resource.UpdateRe
 		patchReq.Protocols = stringToSlice(plan.Protocols.ValueString(), ",")
 	}
 	if !plan.Destination.IsNull() && !plan.Destination.IsUnknown() {
-		patchReq.Destinations = toFirewallRuleObjects(stringToSlice(plan.Destination.ValueString(), ","))
+		patchReq.Destinations = toFirewallRuleObjects(stringToSlice(resolved.Destination, ","))
 	}
 	if !plan.DestinationPorts.IsNull() && !plan.DestinationPorts.IsUnknown() {
 		patchReq.DestinationPorts = stringToSlice(plan.DestinationPorts.ValueString(), ",")
 	}
 	if !plan.Source.IsNull() && !plan.Source.IsUnknown() {
-		patchReq.Sources = toFirewallRuleObjects(stringToSlice(plan.Source.ValueString(), ","))
+		patchReq.Sources = toFirewallRuleObjects(stringToSlice(resolved.Source, ","))
 	}
 	if !plan.SourcePorts.IsNull() && !plan.SourcePorts.IsUnknown() {
 		patchReq.SourcePorts = stringToSlice(plan.SourcePorts.ValueString(), ",")
@@ -249,7 +391,7 @@ func (r *firewallRuleResource) Update(ctx context.Context, req resource.UpdateRe
 
 	dataResp, httpResp, err := r.client.APIClient.VPCFirewallRulesApi.PatchVPCFirewallRule(ctx,
 		patchReq,
-		plan.ProjectID.ValueString(),
+		projectID,
 		plan.ID.ValueString(),
 	)
 	if httpResp != nil {
@@ -262,7 +404,7 @@ func (r *firewallRuleResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	firewallRule, _, err := common.AwaitOperationAndResolve[swagger.VpcFirewallRule](ctx, dataResp.Operation, plan.ProjectID.ValueString(), r.client.APIClient.VPCFirewallRuleOperationsApi.GetNetworkingVPCFirewallRulesOperation)
+	firewallRule, _, err := common.AwaitOperationAndResolve[swagger.VpcFirewallRule](ctx, dataResp.Operation, projectID, r.client.APIClient.VPCFirewallRuleOperationsApi.GetNetworkingVPCFirewallRulesOperation)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to patch firewall rule",
 			fmt.Sprintf("There was an error updating the firewall rule: %s.", common.UnpackAPIError(err)))
@@ -271,6 +413,7 @@ func (r *firewallRuleResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	firewallRuleToTerraformResourceModel(firewallRule, &plan)
+	preserveFirewallRuleReferences(&plan, firewallRule, configured, resolved)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
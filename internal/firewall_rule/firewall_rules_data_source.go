@@ -0,0 +1,201 @@
+package firewall_rule
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+type firewallRulesDataSource struct {
+	client *common.CrusoeClient
+}
+
+type firewallRulesDataSourceModel struct {
+	ProjectID     types.String        `tfsdk:"project_id"`
+	Network       *string             `tfsdk:"network"`
+	Direction     *string             `tfsdk:"direction"`
+	Action        *string             `tfsdk:"action"`
+	Port          *int64              `tfsdk:"port"`
+	CIDR          *string             `tfsdk:"cidr"`
+	FirewallRules []firewallRuleModel `tfsdk:"firewall_rules"`
+}
+
+type firewallRuleModel struct {
+	ID               string `tfsdk:"id"`
+	Name             string `tfsdk:"name"`
+	Network          string `tfsdk:"network"`
+	Action           string `tfsdk:"action"`
+	Direction        string `tfsdk:"direction"`
+	Protocols        string `tfsdk:"protocols"`
+	Source           string `tfsdk:"source"`
+	SourcePorts      string `tfsdk:"source_ports"`
+	Destination      string `tfsdk:"destination"`
+	DestinationPorts string `tfsdk:"destination_ports"`
+}
+
+func NewFirewallRulesDataSource() datasource.DataSource {
+	return &firewallRulesDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (ds *firewallRulesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*common.CrusoeClient)
+	if !ok {
+		resp.Diagnostics.AddError("Failed to initialize provider", common.ErrorMsgProviderInitFailed)
+
+		return
+	}
+
+	ds.client = client
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (ds *firewallRulesDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_vpc_firewall_rules"
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (ds *firewallRulesDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescDataSourceProjectID,
+			},
+			"network": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescNetworkFilter,
+			},
+			"direction": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescDirectionFilter,
+				Validators:          []validator.String{stringvalidator.OneOf("ingress", "egress")},
+			},
+			"action": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescActionFilter,
+				Validators:          []validator.String{stringvalidator.OneOf("allow", "deny")},
+			},
+			"port": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: providerDescPortFilter,
+				Validators:          []validator.Int64{int64validator.Between(1, 65535)},
+			},
+			"cidr": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescCIDRFilter,
+			},
+			"firewall_rules": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: providerDescFirewallRules,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: apiDescID,
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: apiDescName,
+						},
+						"network": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: apiDescNetwork,
+						},
+						"action": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: apiDescAction,
+						},
+						"direction": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: apiDescDirection,
+						},
+						"protocols": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: apiDescProtocols,
+						},
+						"source": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: apiDescSource,
+						},
+						"source_ports": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: apiDescSourcePorts,
+						},
+						"destination": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: apiDescDestination,
+						},
+						"destination_ports": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: apiDescDestinationPorts,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (ds *firewallRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config firewallRulesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var cidr *netip.Prefix
+	if config.CIDR != nil {
+		prefix, err := parseCIDROrAddress(*config.CIDR)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("cidr"), "Invalid CIDR filter", err.Error())
+
+			return
+		}
+		cidr = &prefix
+	}
+
+	projectID := common.GetProjectIDOrFallback(ds.client, config.ProjectID.ValueString())
+
+	dataResp, httpResp, err := ds.client.APIClient.VPCFirewallRulesApi.ListVPCFirewallRules(ctx, projectID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Fetch Firewall Rules",
+			fmt.Sprintf("Could not fetch firewall rule data at this time: %s", common.UnpackAPIError(err)))
+
+		return
+	}
+
+	rules := filterFirewallRules(dataResp.Items, &config, cidr)
+
+	state := config
+	state.FirewallRules = make([]firewallRuleModel, 0, len(rules))
+	for i := range rules {
+		state.FirewallRules = append(state.FirewallRules, firewallRuleToDataSourceModel(&rules[i]))
+	}
+
+	// Sort deterministically so repeated reads produce a stable ordering.
+	common.SortByKeys(state.FirewallRules,
+		func(r firewallRuleModel) string { return r.Name },
+		func(r firewallRuleModel) string { return r.ID },
+	)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,203 @@
+package firewall_rule
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+// Kinds of symbolic source and destination entries, written as "<kind>:<id>".
+const (
+	referenceSubnet        = "subnet"
+	referenceInstance      = "instance"
+	referenceInstanceGroup = "instance_group"
+)
+
+var referenceKinds = []string{referenceSubnet, referenceInstance, referenceInstanceGroup}
+
+// firewallRuleAddresses holds comma-separated source and destination lists.
+type firewallRuleAddresses struct {
+	Source      string
+	Destination string
+}
+
+// addressResolver returns the CIDR blocks a reference of the given kind and ID stands for.
+type addressResolver func(kind, id string) ([]string, error)
+
+// parseFirewallRuleReference splits a symbolic entry such as "subnet:<id>" into its kind and ID.
+// ok is false for entries which are not references.
+func parseFirewallRuleReference(entry string) (kind, id string, ok bool) {
+	kind, id, found := strings.Cut(entry, ":")
+	if !found || !slices.Contains(referenceKinds, kind) {
+		return "", "", false
+	}
+
+	return kind, id, true
+}
+
+// hasFirewallRuleReferences reports whether a comma-separated list contains any references.
+func hasFirewallRuleReferences(list string) bool {
+	for _, entry := range stringToSlice(list, ",") {
+		if _, _, ok := parseFirewallRuleReference(entry); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expandFirewallRuleReferences replaces each reference in a comma-separated list with the CIDR
+// blocks it resolves to. Other entries are kept as they are.
+func expandFirewallRuleReferences(list string, resolve addressResolver) (string, error) {
+	entries := stringToSlice(list, ",")
+	expanded := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		kind, id, ok := parseFirewallRuleReference(entry)
+		if !ok {
+			expanded = append(expanded, entry)
+
+			continue
+		}
+
+		cidrs, err := resolve(kind, id)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve %q: %w", entry, err)
+		}
+		if len(cidrs) == 0 {
+			return "", fmt.Errorf("%q does not currently have any addresses", entry)
+		}
+		expanded = append(expanded, cidrs...)
+	}
+
+	return strings.Join(expanded, ","), nil
+}
+
+// resolveFirewallRuleAddresses expands the references in model's source and destination through
+// the VPC subnet, VM and instance group APIs.
+func resolveFirewallRuleAddresses(ctx context.Context, apiClient *swagger.APIClient, projectID string, model *firewallRuleResourceModel,
+) (firewallRuleAddresses, error) {
+	resolve := func(kind, id string) ([]string, error) {
+		return resolveFirewallRuleReference(ctx, apiClient, projectID, kind, id)
+	}
+
+	source, err := expandFirewallRuleReferences(model.Source.ValueString(), resolve)
+	if err != nil {
+		return firewallRuleAddresses{}, fmt.Errorf("source: %w", err)
+	}
+
+	destination, err := expandFirewallRuleReferences(model.Destination.ValueString(), resolve)
+	if err != nil {
+		return firewallRuleAddresses{}, fmt.Errorf("destination: %w", err)
+	}
+
+	return firewallRuleAddresses{Source: source, Destination: destination}, nil
+}
+
+func resolveFirewallRuleReference(ctx context.Context, apiClient *swagger.APIClient, projectID, kind, id string) ([]string, error) {
+	switch kind {
+	case referenceSubnet:
+		subnet, httpResp, err := apiClient.VPCSubnetsApi.GetVPCSubnet(ctx, projectID, id)
+		if httpResp != nil {
+			defer httpResp.Body.Close()
+		}
+		if err != nil {
+			return nil, common.UnpackAPIError(err)
+		}
+
+		return []string{subnet.Cidr}, nil
+	case referenceInstance:
+		instance, httpResp, err := apiClient.VMsApi.GetInstance(ctx, projectID, id)
+		if httpResp != nil {
+			defer httpResp.Body.Close()
+		}
+		if err != nil {
+			return nil, common.UnpackAPIError(err)
+		}
+
+		return instancePrivateCIDRs(&instance), nil
+	case referenceInstanceGroup:
+		instanceGroup, httpResp, err := apiClient.InstanceGroupsApi.GetInstanceGroup(ctx, id, projectID)
+		if httpResp != nil {
+			defer httpResp.Body.Close()
+		}
+		if err != nil {
+			return nil, common.UnpackAPIError(err)
+		}
+
+		// List the project's VMs once instead of fetching each member of the group.
+		instances, listHTTPResp, err := apiClient.VMsApi.ListInstances(ctx, projectID, &swagger.VMsApiListInstancesOpts{})
+		if listHTTPResp != nil {
+			defer listHTTPResp.Body.Close()
+		}
+		if err != nil {
+			return nil, common.UnpackAPIError(err)
+		}
+
+		return instanceGroupPrivateCIDRs(instanceGroup.ActiveInstances, instances.Items)
+	}
+
+	return nil, fmt.Errorf("unsupported reference kind %q", kind)
+}
+
+// instanceGroupPrivateCIDRs returns the private IPv4 addresses of the given group members, looked
+// up in a list of the project's VMs, as sorted single-address CIDR blocks.
+func instanceGroupPrivateCIDRs(instanceIDs []string, instances []swagger.InstanceV1) ([]string, error) {
+	byID := make(map[string]*swagger.InstanceV1, len(instances))
+	for i := range instances {
+		byID[instances[i].Id] = &instances[i]
+	}
+
+	var cidrs []string
+	for _, instanceID := range instanceIDs {
+		instance, ok := byID[instanceID]
+		if !ok {
+			return nil, fmt.Errorf("instance %s was not found", instanceID)
+		}
+		cidrs = append(cidrs, instancePrivateCIDRs(instance)...)
+	}
+	slices.Sort(cidrs)
+
+	return cidrs, nil
+}
+
+// instancePrivateCIDRs returns the VM's private IPv4 addresses as single-address CIDR blocks.
+func instancePrivateCIDRs(instance *swagger.InstanceV1) []string {
+	var cidrs []string
+	for i := range instance.NetworkInterfaces {
+		for _, ip := range instance.NetworkInterfaces[i].Ips {
+			if ip.PrivateIpv4 != nil && ip.PrivateIpv4.Address != "" {
+				cidrs = append(cidrs, ip.PrivateIpv4.Address+"/32")
+			}
+		}
+	}
+
+	return cidrs
+}
+
+// preserveReferenceFormat keeps a configured list containing references while the addresses it
+// resolves to still match what the API has. Otherwise the API's addresses are returned, so the
+// drift shows up in the next plan and the rule is updated with the new addresses.
+func preserveReferenceFormat(configured, resolved string, apiElems []string) string {
+	if listsSemanticallyEqual(resolved, apiElems, false) {
+		return configured
+	}
+
+	return strings.Join(apiElems, ",")
+}
+
+// preserveFirewallRuleReferences restores configured references in state after
+// firewallRuleToTerraformResourceModel has replaced them with the API's addresses.
+func preserveFirewallRuleReferences(state *firewallRuleResourceModel, rule *swagger.VpcFirewallRule, configured, resolved firewallRuleAddresses) {
+	if hasFirewallRuleReferences(configured.Source) {
+		state.Source = types.StringValue(preserveReferenceFormat(configured.Source, resolved.Source, cidrList(rule.Sources)))
+	}
+	if hasFirewallRuleReferences(configured.Destination) {
+		state.Destination = types.StringValue(preserveReferenceFormat(configured.Destination, resolved.Destination, cidrList(rule.Destinations)))
+	}
+}
@@ -0,0 +1,133 @@
+package firewall_rule
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+)
+
+func testResolver(kind, id string) ([]string, error) {
+	switch kind + ":" + id {
+	case "subnet:sn-1":
+		return []string{"10.1.0.0/24"}, nil
+	case "instance:vm-1":
+		return []string{"10.1.0.5/32"}, nil
+	case "instance_group:empty":
+		return nil, nil
+	}
+
+	return nil, errors.New("not found")
+}
+
+func Test_expandFirewallRuleReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		list    string
+		want    string
+		wantErr bool
+	}{
+		{name: "no references", list: "10.0.0.0/8", want: "10.0.0.0/8"},
+		{name: "mixed", list: "subnet:sn-1, 192.168.0.0/16, instance:vm-1", want: "10.1.0.0/24,192.168.0.0/16,10.1.0.5/32"},
+		{name: "unresolvable", list: "subnet:missing", wantErr: true},
+		{name: "no addresses", list: "instance_group:empty", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandFirewallRuleReferences(tt.list, testResolver)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandFirewallRuleReferences() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("expandFirewallRuleReferences() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_instanceGroupPrivateCIDRs(t *testing.T) {
+	instance := func(id, address string) swagger.InstanceV1 {
+		return swagger.InstanceV1{Id: id, NetworkInterfaces: []swagger.NetworkInterface{{
+			Ips: []swagger.IpAddresses{{PrivateIpv4: &swagger.PrivateIpv4Address{Address: address}}},
+		}}}
+	}
+	instances := []swagger.InstanceV1{
+		instance("vm-1", "10.0.0.7"),
+		instance("vm-2", "10.0.0.5"),
+		instance("vm-3", "10.0.0.6"),
+	}
+
+	tests := []struct {
+		name        string
+		instanceIDs []string
+		want        []string
+		wantErr     bool
+	}{
+		{name: "members only, sorted", instanceIDs: []string{"vm-1", "vm-2"}, want: []string{"10.0.0.5/32", "10.0.0.7/32"}},
+		{name: "no members", instanceIDs: nil, want: nil},
+		{name: "missing member", instanceIDs: []string{"vm-1", "vm-4"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := instanceGroupPrivateCIDRs(tt.instanceIDs, instances)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("instanceGroupPrivateCIDRs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("instanceGroupPrivateCIDRs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_preserveFirewallRuleReferences(t *testing.T) {
+	configured := firewallRuleAddresses{Source: "subnet:sn-1", Destination: "0.0.0.0/0"}
+	resolved := firewallRuleAddresses{Source: "10.1.0.0/24", Destination: "0.0.0.0/0"}
+
+	t.Run("unchanged addresses keep the reference", func(t *testing.T) {
+		rule := &swagger.VpcFirewallRule{
+			Sources:      []swagger.FirewallRuleObject{{Cidr: "10.1.0.0/24"}},
+			Destinations: []swagger.FirewallRuleObject{{Cidr: "0.0.0.0/0"}},
+		}
+		state := &firewallRuleResourceModel{Source: types.StringValue("10.1.0.0/24"), Destination: types.StringValue("0.0.0.0/0")}
+
+		preserveFirewallRuleReferences(state, rule, configured, resolved)
+
+		if got := state.Source.ValueString(); got != "subnet:sn-1" {
+			t.Errorf("source = %q, want the configured reference", got)
+		}
+	})
+
+	t.Run("changed addresses show as drift", func(t *testing.T) {
+		rule := &swagger.VpcFirewallRule{
+			Sources:      []swagger.FirewallRuleObject{{Cidr: "10.9.0.0/24"}},
+			Destinations: []swagger.FirewallRuleObject{{Cidr: "0.0.0.0/0"}},
+		}
+		state := &firewallRuleResourceModel{Source: types.StringValue("10.9.0.0/24"), Destination: types.StringValue("0.0.0.0/0")}
+
+		preserveFirewallRuleReferences(state, rule, configured, resolved)
+
+		if got := state.Source.ValueString(); got != "10.9.0.0/24" {
+			t.Errorf("source = %q, want the API's addresses", got)
+		}
+	})
+}
+
+func Test_validateFirewallRuleAddresses_references(t *testing.T) {
+	var diags diag.Diagnostics
+	validateFirewallRuleAddresses(path.Root("source"), "subnet:sn-1,instance_group:ig-1", &diags)
+	if len(diags) != 0 {
+		t.Errorf("unexpected diagnostics for references: %v", diags)
+	}
+
+	diags = nil
+	validateFirewallRuleAddresses(path.Root("source"), "instance:", &diags)
+	if !diags.HasError() {
+		t.Error("want an error for a reference without an ID")
+	}
+}
@@ -2,6 +2,8 @@ package firewall_rule
 
 import (
 	"context"
+	"fmt"
+	"net/netip"
 	"regexp"
 	"slices"
 	"strings"
@@ -29,7 +31,28 @@ const (
 
 // providerDesc* — provider-specific schema descriptions (Terraform-side; not from the spec).
 const (
-	providerDescProjectID = "ID of the project the firewall rule belongs to. " + project.ProviderDescProjectIDFallback
+	providerDescProjectID         = "ID of the project the firewall rule belongs to. " + project.ProviderDescProjectIDFallback
+	providerDescAddressReferences = "Entries may also be references of the form `subnet:<id>`, `instance:<id>` or `instance_group:<id>`, " +
+		"which are resolved to the subnet's CIDR block or the VMs' private IPv4 addresses when the rule is applied. " +
+		"A change in a referenced object's addresses is detected as drift."
+
+	providerDescDataSourceProjectID = "ID of the project to list firewall rules in. " + project.ProviderDescProjectIDFallback
+	providerDescFirewallRules       = "Firewall rules matching all of the given filters, sorted by name. " +
+		"List attributes are normalized: whitespace is removed, `*` ports are expanded to `1-65535`, and elements are sorted."
+	providerDescNetworkFilter   = "Only return rules belonging to this VPC network ID."
+	providerDescDirectionFilter = "Only return rules with this direction. Possible values: `ingress`, `egress`."
+	providerDescActionFilter    = "Only return rules with this action. Possible values: `allow`, `deny`."
+	providerDescPortFilter      = "Only return rules whose destination ports include this port."
+	providerDescCIDRFilter      = "Only return rules with a source or destination CIDR containing this IP address or CIDR block."
+
+	providerDescPolicyID        = "ID of the firewall policy. This is the ID of the VPC network it manages."
+	providerDescPolicyProjectID = "ID of the project the VPC network belongs to. " + project.ProviderDescProjectIDFallback
+	providerDescPolicyRules     = "The complete set of firewall rules for the network, matched to existing rules by name. " +
+		"Rules are created, updated, or replaced as needed; rules in the network which are not listed are unmanaged."
+	providerDescUnmanagedRules = "What to do with rules in the network which are not listed in `rules`. " +
+		"`delete` (the default) deletes them; `warn` leaves them in place and reports them as a warning, " +
+		"for reviewing what adopting the policy would remove. Possible values: `delete`, `warn`."
+	providerDescRuleIDs = "IDs of the managed firewall rules, keyed by rule name."
 )
 
 var whitespaceRegex = regexp.MustCompile(`\s*`)
@@ -153,3 +176,92 @@ func firewallRuleToTerraformResourceModel(rule *swagger.VpcFirewallRule, state *
 	state.Destination = types.StringValue(preserveListFormat(state.Destination.ValueString(), cidrList(rule.Destinations), false))
 	state.DestinationPorts = types.StringValue(preserveListFormat(state.DestinationPorts.ValueString(), rule.DestinationPorts, true))
 }
+
+// firewallRuleToDataSourceModel converts a rule to its data source representation, with each list
+// normalized by canonicalizeList so equivalent spellings compare equal.
+func firewallRuleToDataSourceModel(rule *swagger.VpcFirewallRule) firewallRuleModel {
+	return firewallRuleModel{
+		ID:               rule.Id,
+		Name:             rule.Name,
+		Network:          rule.VpcNetworkId,
+		Action:           rule.Action,
+		Direction:        rule.Direction,
+		Protocols:        strings.Join(canonicalizeList(rule.Protocols, false), ","),
+		Source:           strings.Join(canonicalizeList(cidrList(rule.Sources), false), ","),
+		SourcePorts:      strings.Join(canonicalizeList(rule.SourcePorts, true), ","),
+		Destination:      strings.Join(canonicalizeList(cidrList(rule.Destinations), false), ","),
+		DestinationPorts: strings.Join(canonicalizeList(rule.DestinationPorts, true), ","),
+	}
+}
+
+// filterFirewallRules returns the rules matching every filter set in config. cidr is the parsed
+// cidr filter, or nil if it is unset.
+func filterFirewallRules(rules []swagger.VpcFirewallRule, config *firewallRulesDataSourceModel, cidr *netip.Prefix) []swagger.VpcFirewallRule {
+	filtered := make([]swagger.VpcFirewallRule, 0, len(rules))
+	for i := range rules {
+		rule := &rules[i]
+		if config.Network != nil && rule.VpcNetworkId != *config.Network {
+			continue
+		}
+		if config.Direction != nil && rule.Direction != *config.Direction {
+			continue
+		}
+		if config.Action != nil && rule.Action != *config.Action {
+			continue
+		}
+		if config.Port != nil && !portRangesContain(rule.DestinationPorts, *config.Port) {
+			continue
+		}
+		if cidr != nil && !cidrsContain(cidrList(rule.Sources), *cidr) && !cidrsContain(cidrList(rule.Destinations), *cidr) {
+			continue
+		}
+
+		filtered = append(filtered, *rule)
+	}
+
+	return filtered
+}
+
+// portRangesContain reports whether port falls within any of the given ports or port ranges.
+// An empty list or "*" means all ports, as for canonicalizeList.
+func portRangesContain(ranges []string, port int64) bool {
+	for _, r := range canonicalizeList(ranges, true) {
+		low, high, err := parsePortRange(r)
+		if err == nil && port >= low && port <= high {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cidrsContain reports whether any of cidrs contains the whole of target. Entries which are not
+// IP addresses or CIDR blocks, such as resource IDs, never match.
+func cidrsContain(cidrs []string, target netip.Prefix) bool {
+	for _, c := range cidrs {
+		prefix, err := parseCIDROrAddress(strings.TrimSpace(c))
+		if err != nil {
+			continue
+		}
+
+		if prefix.Addr().Is4() == target.Addr().Is4() && prefix.Bits() <= target.Bits() && prefix.Contains(target.Addr()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseCIDROrAddress parses a CIDR block, or a bare IP address as a single-address prefix.
+func parseCIDROrAddress(s string) (netip.Prefix, error) {
+	if prefix, err := netip.ParsePrefix(s); err == nil {
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%q is not an IP address or CIDR block", s)
+	}
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
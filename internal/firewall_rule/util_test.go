@@ -1,6 +1,7 @@
 package firewall_rule
 
 import (
+	"net/netip"
 	"reflect"
 	"testing"
 
@@ -205,3 +206,99 @@ func Test_firewallRuleToTerraformResourceModel_reflectsAPIChange(t *testing.T) {
 		t.Errorf("source_ports = %q, want %q (from API)", got, "443")
 	}
 }
+
+func Test_portRangesContain(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges []string
+		port   int64
+		want   bool
+	}{
+		{name: "single port", ranges: []string{"443"}, port: 443, want: true},
+		{name: "inside range", ranges: []string{"22", "8000-9000"}, port: 8080, want: true},
+		{name: "outside range", ranges: []string{"8000-9000"}, port: 443, want: false},
+		{name: "wildcard", ranges: []string{"*"}, port: 22, want: true},
+		{name: "empty means all ports", ranges: []string{}, port: 22, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := portRangesContain(tt.ranges, tt.port); got != tt.want {
+				t.Errorf("portRangesContain(%v, %d) = %v, want %v", tt.ranges, tt.port, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_cidrsContain(t *testing.T) {
+	tests := []struct {
+		name   string
+		cidrs  []string
+		target string
+		want   bool
+	}{
+		{name: "address in block", cidrs: []string{"10.0.0.0/8"}, target: "10.1.2.3", want: true},
+		{name: "smaller block in block", cidrs: []string{"10.0.0.0/8"}, target: "10.1.0.0/16", want: true},
+		{name: "larger block not contained", cidrs: []string{"10.1.0.0/16"}, target: "10.0.0.0/8", want: false},
+		{name: "bare address", cidrs: []string{"192.168.1.1"}, target: "192.168.1.1", want: true},
+		{name: "resource IDs never match", cidrs: []string{"vpc-subnet-1"}, target: "10.1.2.3", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := parseCIDROrAddress(tt.target)
+			if err != nil {
+				t.Fatalf("parseCIDROrAddress(%q) error = %v", tt.target, err)
+			}
+			if got := cidrsContain(tt.cidrs, target); got != tt.want {
+				t.Errorf("cidrsContain(%v, %s) = %v, want %v", tt.cidrs, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_filterFirewallRules(t *testing.T) {
+	rules := []swagger.VpcFirewallRule{
+		{
+			Id: "fw-ssh", VpcNetworkId: "net-1", Action: "allow", Direction: "ingress",
+			Sources: []swagger.FirewallRuleObject{{Cidr: "0.0.0.0/0"}}, DestinationPorts: []string{"22"},
+		},
+		{
+			Id: "fw-web", VpcNetworkId: "net-1", Action: "allow", Direction: "ingress",
+			Sources: []swagger.FirewallRuleObject{{Cidr: "10.0.0.0/8"}}, DestinationPorts: []string{"80", "443"},
+		},
+		{
+			Id: "fw-deny", VpcNetworkId: "net-2", Action: "deny", Direction: "egress",
+			Destinations: []swagger.FirewallRuleObject{{Cidr: "192.168.0.0/16"}}, DestinationPorts: []string{"*"},
+		},
+	}
+
+	network, deny := "net-1", "deny"
+	port := int64(443)
+	cidr, err := parseCIDROrAddress("192.168.4.0/24")
+	if err != nil {
+		t.Fatalf("parseCIDROrAddress() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		config firewallRulesDataSourceModel
+		cidr   *netip.Prefix
+		want   []string
+	}{
+		{name: "no filters", want: []string{"fw-ssh", "fw-web", "fw-deny"}},
+		{name: "network", config: firewallRulesDataSourceModel{Network: &network}, want: []string{"fw-ssh", "fw-web"}},
+		{name: "action", config: firewallRulesDataSourceModel{Action: &deny}, want: []string{"fw-deny"}},
+		{name: "port", config: firewallRulesDataSourceModel{Port: &port}, want: []string{"fw-web", "fw-deny"}},
+		{name: "cidr contained by source or destination", cidr: &cidr, want: []string{"fw-ssh", "fw-deny"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, rule := range filterFirewallRules(rules, &tt.config, tt.cidr) {
+				got = append(got, rule.Id)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterFirewallRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package firewall_rule
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+)
+
+const sshPort = 22
+
+// knownProtocols are the protocols the checks understand. Others are warned about rather than
+// rejected, so protocols newly supported by the API aren't blocked.
+var knownProtocols = []string{"tcp", "udp", "icmp"}
+
+// isAddressEntry reports whether a source or destination entry is meant as an IP address or CIDR
+// block rather than a resource ID or reference.
+func isAddressEntry(entry string) bool {
+	if _, _, ok := parseFirewallRuleReference(entry); ok {
+		return false
+	}
+
+	return strings.ContainsAny(entry, ".:/")
+}
+
+// validateFirewallRuleAddresses checks each CIDR block and IP address in a comma-separated source
+// or destination list. Resource IDs are left to the API.
+func validateFirewallRuleAddresses(attr path.Path, list string, diags *diag.Diagnostics) {
+	entries := stringToSlice(list, ",")
+	if len(entries) == 0 {
+		diags.AddAttributeError(attr, "Invalid firewall rule address", "At least one CIDR block or resource ID is required.")
+
+		return
+	}
+
+	for _, entry := range entries {
+		if kind, id, ok := parseFirewallRuleReference(entry); ok && id == "" {
+			diags.AddAttributeError(attr, "Invalid firewall rule reference",
+				fmt.Sprintf("%q is missing an ID, for example %s:<id>.", entry, kind))
+
+			continue
+		}
+
+		if !isAddressEntry(entry) {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			if _, err := netip.ParseAddr(entry); err != nil {
+				diags.AddAttributeError(attr, "Invalid firewall rule address",
+					fmt.Sprintf("%q is not a valid IP address or CIDR block: %s", entry, err))
+			}
+
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			diags.AddAttributeError(attr, "Invalid firewall rule address",
+				fmt.Sprintf("%q is not a valid CIDR block: %s", entry, err))
+
+			continue
+		}
+
+		if masked := prefix.Masked(); masked != prefix {
+			diags.AddAttributeWarning(attr, "CIDR block has host bits set",
+				fmt.Sprintf("%q has host bits set, so it matches all of %s. Use %s if that is intended.", entry, masked, masked))
+		}
+	}
+}
+
+// validateFirewallRulePorts checks each port or port range in a comma-separated port list.
+func validateFirewallRulePorts(attr path.Path, list string, diags *diag.Diagnostics) {
+	for _, entry := range stringToSlice(list, ",") {
+		if entry == "*" {
+			continue
+		}
+
+		if _, _, err := parsePortRange(entry); err != nil {
+			diags.AddAttributeError(attr, "Invalid firewall rule port", err.Error())
+		}
+	}
+}
+
+// validateFirewallRuleProtocols checks a comma-separated protocol list.
+func validateFirewallRuleProtocols(attr path.Path, list string, diags *diag.Diagnostics) {
+	entries := stringToSlice(list, ",")
+	if len(entries) == 0 {
+		diags.AddAttributeError(attr, "Invalid firewall rule protocol", "At least one protocol is required.")
+
+		return
+	}
+
+	for _, entry := range entries {
+		if !slices.Contains(knownProtocols, strings.ToLower(entry)) {
+			diags.AddAttributeWarning(attr, "Unrecognized firewall rule protocol",
+				fmt.Sprintf("%q is not one of the recognized protocols (%s), so it may be rejected by the API.",
+					entry, strings.Join(knownProtocols, ", ")))
+		}
+	}
+}
+
+// parsePortRange parses a single port or a port range such as "3000-8080".
+func parsePortRange(entry string) (low, high int64, err error) {
+	lowStr, highStr, found := strings.Cut(entry, "-")
+	if !found {
+		highStr = lowStr
+	}
+
+	low, errLow := strconv.ParseInt(lowStr, 10, 64)
+	high, errHigh := strconv.ParseInt(highStr, 10, 64)
+	switch {
+	case errLow != nil || errHigh != nil:
+		return 0, 0, fmt.Errorf("%q is not a port or port range, such as 443 or 3000-8080", entry)
+	case low < 1 || high > 65535:
+		return 0, 0, fmt.Errorf("%q is outside the valid port range 1-65535", entry)
+	case low > high:
+		return 0, 0, fmt.Errorf("%q has its start port after its end port", entry)
+	}
+
+	return low, high, nil
+}
+
+// sshOpenToInternet reports whether rule allows inbound SSH from any address.
+func sshOpenToInternet(rule *swagger.VpcFirewallRule) bool {
+	if rule.Direction != "ingress" || rule.Action != "allow" {
+		return false
+	}
+
+	if !slices.Contains(canonicalizeProtocols(rule.Protocols), "tcp") || !portRangesContain(rule.DestinationPorts, sshPort) {
+		return false
+	}
+
+	for _, source := range cidrList(rule.Sources) {
+		if prefix, err := netip.ParsePrefix(strings.TrimSpace(source)); err == nil && prefix.Bits() == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// firewallRulesEquivalent reports whether two rules match the same traffic with the same action.
+func firewallRulesEquivalent(a, b *swagger.VpcFirewallRule) bool {
+	return a.Action == b.Action && a.Direction == b.Direction &&
+		slices.Equal(canonicalizeProtocols(a.Protocols), canonicalizeProtocols(b.Protocols)) &&
+		slices.Equal(canonicalizeList(cidrList(a.Sources), false), canonicalizeList(cidrList(b.Sources), false)) &&
+		slices.Equal(canonicalizeList(a.SourcePorts, true), canonicalizeList(b.SourcePorts, true)) &&
+		slices.Equal(canonicalizeList(cidrList(a.Destinations), false), canonicalizeList(cidrList(b.Destinations), false)) &&
+		slices.Equal(canonicalizeList(a.DestinationPorts, true), canonicalizeList(b.DestinationPorts, true))
+}
+
+// firewallRuleCovers reports whether outer matches all the traffic inner matches, in which case
+// inner is shadowed by outer.
+func firewallRuleCovers(outer, inner *swagger.VpcFirewallRule) bool {
+	if outer.Direction != inner.Direction {
+		return false
+	}
+
+	outerProtocols := canonicalizeProtocols(outer.Protocols)
+	for _, protocol := range canonicalizeProtocols(inner.Protocols) {
+		if !slices.Contains(outerProtocols, protocol) {
+			return false
+		}
+	}
+
+	return addressesCover(cidrList(outer.Sources), cidrList(inner.Sources)) &&
+		portsCover(outer.SourcePorts, inner.SourcePorts) &&
+		addressesCover(cidrList(outer.Destinations), cidrList(inner.Destinations)) &&
+		portsCover(outer.DestinationPorts, inner.DestinationPorts)
+}
+
+// addressesCover reports whether every inner entry is contained in an outer CIDR block or, for
+// resource IDs, listed in outer.
+func addressesCover(outer, inner []string) bool {
+	for _, entry := range canonicalizeList(inner, false) {
+		if !isAddressEntry(entry) {
+			if !slices.Contains(canonicalizeList(outer, false), entry) {
+				return false
+			}
+
+			continue
+		}
+
+		prefix, err := parseCIDROrAddress(entry)
+		if err != nil || !cidrsContain(outer, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// portsCover reports whether every inner port range lies within a single outer port range.
+func portsCover(outer, inner []string) bool {
+	for _, entry := range canonicalizeList(inner, true) {
+		low, high, err := parsePortRange(entry)
+		if err != nil {
+			return false
+		}
+
+		covered := false
+		for _, outerEntry := range canonicalizeList(outer, true) {
+			outerLow, outerHigh, err := parsePortRange(outerEntry)
+			if err == nil && outerLow <= low && high <= outerHigh {
+				covered = true
+
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+
+	return true
+}
+
+func canonicalizeProtocols(protocols []string) []string {
+	lower := make([]string, 0, len(protocols))
+	for _, protocol := range protocols {
+		lower = append(lower, strings.ToLower(protocol))
+	}
+
+	return canonicalizeList(lower, false)
+}
+
+// firewallRuleFromResourceModel converts a planned rule to its API representation, for comparing
+// it with existing rules.
+func firewallRuleFromResourceModel(model *firewallRuleResourceModel) swagger.VpcFirewallRule {
+	return swagger.VpcFirewallRule{
+		Id:               model.ID.ValueString(),
+		Name:             model.Name.ValueString(),
+		VpcNetworkId:     model.Network.ValueString(),
+		Action:           model.Action.ValueString(),
+		Direction:        model.Direction.ValueString(),
+		Protocols:        stringToSlice(model.Protocols.ValueString(), ","),
+		Sources:          toFirewallRuleObjects(stringToSlice(model.Source.ValueString(), ",")),
+		SourcePorts:      stringToSlice(model.SourcePorts.ValueString(), ","),
+		Destinations:     toFirewallRuleObjects(stringToSlice(model.Destination.ValueString(), ",")),
+		DestinationPorts: stringToSlice(model.DestinationPorts.ValueString(), ","),
+	}
+}
@@ -0,0 +1,142 @@
+package firewall_rule
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+)
+
+func Test_validateFirewallRuleAddresses(t *testing.T) {
+	tests := []struct {
+		name        string
+		list        string
+		wantErr     bool
+		wantWarning bool
+	}{
+		{name: "valid CIDRs", list: "10.0.0.0/8, 192.168.1.0/24"},
+		{name: "bare address", list: "203.0.113.7"},
+		{name: "resource ID", list: "a1b2c3d4-0000-1111-2222-333344445555"},
+		{name: "IPv6", list: "2001:db8::/32"},
+		{name: "bad prefix length", list: "10.0.0.0/33", wantErr: true},
+		{name: "bad address", list: "10.0.0.256", wantErr: true},
+		{name: "empty", list: " ", wantErr: true},
+		{name: "host bits set", list: "10.0.0.1/8", wantWarning: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateFirewallRuleAddresses(path.Root("source"), tt.list, &diags)
+			if got := diags.HasError(); got != tt.wantErr {
+				t.Errorf("error = %v, want %v: %v", got, tt.wantErr, diags)
+			}
+			if got := diags.WarningsCount() > 0; got != tt.wantWarning {
+				t.Errorf("warning = %v, want %v: %v", got, tt.wantWarning, diags)
+			}
+		})
+	}
+}
+
+func Test_validateFirewallRulePorts(t *testing.T) {
+	tests := []struct {
+		name    string
+		list    string
+		wantErr bool
+	}{
+		{name: "single ports and ranges", list: "22, 80, 3000-8080"},
+		{name: "wildcard", list: "*"},
+		{name: "empty means all ports", list: ""},
+		{name: "port zero", list: "0", wantErr: true},
+		{name: "port too large", list: "65536", wantErr: true},
+		{name: "reversed range", list: "8080-3000", wantErr: true},
+		{name: "not a number", list: "ssh", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateFirewallRulePorts(path.Root("destination_ports"), tt.list, &diags)
+			if got := diags.HasError(); got != tt.wantErr {
+				t.Errorf("error = %v, want %v: %v", got, tt.wantErr, diags)
+			}
+		})
+	}
+}
+
+func Test_validateFirewallRuleProtocols(t *testing.T) {
+	var diags diag.Diagnostics
+	validateFirewallRuleProtocols(path.Root("protocols"), "TCP,udp", &diags)
+	if len(diags) != 0 {
+		t.Errorf("unexpected diagnostics for known protocols: %v", diags)
+	}
+
+	diags = nil
+	validateFirewallRuleProtocols(path.Root("protocols"), "sctp", &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("want one warning for an unrecognized protocol, got %v", diags)
+	}
+
+	diags = nil
+	validateFirewallRuleProtocols(path.Root("protocols"), "", &diags)
+	if !diags.HasError() {
+		t.Error("want an error for an empty protocol list")
+	}
+}
+
+func Test_sshOpenToInternet(t *testing.T) {
+	rule := swagger.VpcFirewallRule{
+		Action:           "allow",
+		Direction:        "ingress",
+		Protocols:        []string{"tcp"},
+		Sources:          []swagger.FirewallRuleObject{{Cidr: "0.0.0.0/0"}},
+		DestinationPorts: []string{"20-25"},
+	}
+	if !sshOpenToInternet(&rule) {
+		t.Error("sshOpenToInternet() = false for SSH allowed from 0.0.0.0/0")
+	}
+
+	restricted := rule
+	restricted.Sources = []swagger.FirewallRuleObject{{Cidr: "10.0.0.0/8"}}
+	if sshOpenToInternet(&restricted) {
+		t.Error("sshOpenToInternet() = true for a restricted source")
+	}
+
+	egress := rule
+	egress.Direction = "egress"
+	if sshOpenToInternet(&egress) {
+		t.Error("sshOpenToInternet() = true for an egress rule")
+	}
+}
+
+func Test_firewallRuleCovers(t *testing.T) {
+	outer := swagger.VpcFirewallRule{
+		Direction:        "ingress",
+		Protocols:        []string{"tcp", "udp"},
+		Sources:          []swagger.FirewallRuleObject{{Cidr: "10.0.0.0/8"}},
+		SourcePorts:      []string{wildcardPortRange},
+		Destinations:     []swagger.FirewallRuleObject{{Cidr: "0.0.0.0/0"}},
+		DestinationPorts: []string{"1-1024"},
+	}
+	inner := swagger.VpcFirewallRule{
+		Direction:        "ingress",
+		Protocols:        []string{"tcp"},
+		Sources:          []swagger.FirewallRuleObject{{Cidr: "10.1.0.0/16"}},
+		SourcePorts:      []string{"*"},
+		Destinations:     []swagger.FirewallRuleObject{{Cidr: "192.168.0.0/16"}},
+		DestinationPorts: []string{"22", "443"},
+	}
+
+	if !firewallRuleCovers(&outer, &inner) {
+		t.Error("firewallRuleCovers() = false, want true for a narrower rule")
+	}
+	if firewallRuleCovers(&inner, &outer) {
+		t.Error("firewallRuleCovers() = true, want false for a broader rule")
+	}
+
+	widerPorts := inner
+	widerPorts.DestinationPorts = []string{"443", "8443"}
+	if firewallRuleCovers(&outer, &widerPorts) {
+		t.Error("firewallRuleCovers() = true, want false when a port is outside the outer rule")
+	}
+}
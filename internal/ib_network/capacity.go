@@ -0,0 +1,130 @@
+package ib_network
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+// CapacityRequest describes instances a plan would add to an InfiniBand partition. Each resource
+// checks only its own instances: several resources planned together on one partition can each fit
+// while exceeding the capacity between them, which is then left for the API to reject.
+type CapacityRequest struct {
+	ProjectID    string
+	PartitionID  string
+	InstanceType string
+	Count        int64
+	// Attribute is the path diagnostics are attached to.
+	Attribute path.Path
+	// WarnOnly reports a shortfall as a warning instead of an error, for resources which don't
+	// create instances themselves.
+	WarnOnly bool
+}
+
+// CheckCapacity resolves the partition's IB network and reports when it doesn't have room for the
+// requested instances. Failing to look up the capacity, or finding no capacity entry for the
+// instance type, only warns, since the API remains the final authority.
+func CheckCapacity(ctx context.Context, apiClient *swagger.APIClient, req *CapacityRequest, diags *diag.Diagnostics) {
+	if req.PartitionID == "" || req.InstanceType == "" || req.Count <= 0 {
+		return
+	}
+
+	network, err := partitionNetwork(ctx, apiClient, req.ProjectID, req.PartitionID)
+	if err != nil {
+		diags.AddAttributeWarning(req.Attribute, "Unable to check InfiniBand capacity",
+			fmt.Sprintf("The capacity of IB partition %s could not be checked: %s", req.PartitionID, err))
+
+		return
+	}
+
+	available, ok := availableCapacity(network.Capacities, req.InstanceType)
+	if !ok {
+		diags.AddAttributeWarning(req.Attribute, "Unknown InfiniBand capacity",
+			fmt.Sprintf("IB network %s (%s) reports no capacity for slice type %s, so the capacity for this instance type could not be checked.",
+				network.Name, network.Id, req.InstanceType))
+
+		return
+	}
+
+	required := req.Count * slicesPerInstance(req.InstanceType)
+	if required <= available {
+		return
+	}
+
+	detail := fmt.Sprintf("The plan requests %d %s instance(s), %d slices, on IB partition %s, but IB network %s (%s) only has %d slices free."+
+		" Instances planned by other resources are not included in this count.",
+		req.Count, req.InstanceType, required, req.PartitionID, network.Name, network.Id, available)
+	if req.WarnOnly {
+		diags.AddAttributeWarning(req.Attribute, "Insufficient InfiniBand capacity", detail)
+	} else {
+		diags.AddAttributeError(req.Attribute, "Insufficient InfiniBand capacity", detail)
+	}
+}
+
+// partitionNetwork returns the IB network the partition belongs to.
+func partitionNetwork(ctx context.Context, apiClient *swagger.APIClient, projectID, partitionID string) (*swagger.IbNetwork, error) {
+	partition, httpResp, err := apiClient.IBPartitionsApi.GetIBPartition(ctx, projectID, partitionID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IB partition: %w", common.UnpackAPIError(err))
+	}
+
+	networks, networksResp, err := apiClient.IBNetworksApi.ListIBNetworks(ctx, projectID)
+	if networksResp != nil {
+		defer networksResp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IB networks: %w", common.UnpackAPIError(err))
+	}
+
+	for i := range networks.Items {
+		if networks.Items[i].Id == partition.IbNetworkId {
+			return &networks.Items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("IB network %s was not found", partition.IbNetworkId)
+}
+
+// availableCapacity returns the remaining number of slices of the given type, and whether the
+// network reports the type at all.
+//
+// The API only describes slice_type as the "VM slice type the capacity applies to" and doesn't
+// define how it relates to instance types. Matching it against the instance type follows
+// examples/infiniband/main.tf, which compares the two for equality; the comparison here also
+// ignores case. That mapping is an assumption, so a type without an entry is not checked.
+func availableCapacity(capacities []swagger.IbNetworkCapacity, sliceType string) (int64, bool) {
+	for _, c := range capacities {
+		if strings.EqualFold(c.SliceType, sliceType) {
+			return int64(c.Quantity), true
+		}
+	}
+
+	return 0, false
+}
+
+// slicesPerInstance returns the number of slices an instance of the given type takes, read from
+// the ".<n>x" suffix of its name, or 1 when there is none. examples/infiniband/main.tf likewise
+// multiplies the number of VMs by their slices, 8 for an "a100-80gb-sxm-ib.8x" VM.
+func slicesPerInstance(instanceType string) int64 {
+	dot := strings.LastIndex(instanceType, ".")
+	if dot < 0 {
+		return 1
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSuffix(instanceType[dot+1:], "x"), 10, 64)
+	if err != nil || n < 1 {
+		return 1
+	}
+
+	return n
+}
@@ -0,0 +1,75 @@
+package ib_network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+)
+
+func TestAvailableCapacity(t *testing.T) {
+	capacities := []swagger.IbNetworkCapacity{
+		{Quantity: 12, SliceType: "h100-80gb-sxm-ib.8x"},
+		{Quantity: 0, SliceType: "a100-80gb-sxm-ib.8x"},
+	}
+
+	tests := []struct {
+		name      string
+		sliceType string
+		want      int64
+		wantOK    bool
+	}{
+		{name: "exact match", sliceType: "h100-80gb-sxm-ib.8x", want: 12, wantOK: true},
+		{name: "case insensitive", sliceType: "H100-80GB-SXM-IB.8x", want: 12, wantOK: true},
+		{name: "exhausted", sliceType: "a100-80gb-sxm-ib.8x", want: 0, wantOK: true},
+		{name: "unknown type", sliceType: "l40s-48gb.8x", want: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := availableCapacity(capacities, tt.sliceType)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("availableCapacity(%q) = (%d, %t), want (%d, %t)", tt.sliceType, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSlicesPerInstance(t *testing.T) {
+	tests := map[string]int64{
+		"h100-80gb-sxm-ib.8x": 8,
+		"a40.4x":              4,
+		"c1a.16x":             16,
+		"custom-type":         1,
+		"odd.suffix":          1,
+	}
+
+	for instanceType, want := range tests {
+		if got := slicesPerInstance(instanceType); got != want {
+			t.Errorf("slicesPerInstance(%q) = %d, want %d", instanceType, got, want)
+		}
+	}
+}
+
+// TestCheckCapacitySkipsEmptyRequests verifies nothing is looked up when the plan adds no
+// instances to a partition; a nil client would panic otherwise.
+func TestCheckCapacitySkipsEmptyRequests(t *testing.T) {
+	requests := []CapacityRequest{
+		{InstanceType: "h100-80gb-sxm-ib.8x", Count: 8},
+		{PartitionID: "ibp-1", Count: 8},
+		{PartitionID: "ibp-1", InstanceType: "h100-80gb-sxm-ib.8x"},
+	}
+
+	for i := range requests {
+		requests[i].Attribute = path.Root("ib_partition_id")
+
+		var diags diag.Diagnostics
+		CheckCapacity(context.Background(), nil, &requests[i], &diags)
+		if diags.WarningsCount() != 0 || diags.HasError() {
+			t.Errorf("request %d: unexpected diagnostics %v", i, diags)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package ib_network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+// ibNetworkSelectionDataSource picks the IB network in a location which best fits a request.
+type ibNetworkSelectionDataSource struct {
+	client *common.CrusoeClient
+}
+
+type ibNetworkSelectionDataSourceModel struct {
+	ProjectID types.String `tfsdk:"project_id"`
+	Location  types.String `tfsdk:"location"`
+	SliceType types.String `tfsdk:"slice_type"`
+	Count     types.Int64  `tfsdk:"count"`
+	Policy    types.String `tfsdk:"policy"`
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Available types.Int64  `tfsdk:"available"`
+}
+
+func NewIBNetworkSelectionDataSource() datasource.DataSource {
+	return &ibNetworkSelectionDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (ds *ibNetworkSelectionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*common.CrusoeClient)
+	if !ok {
+		resp.Diagnostics.AddError("Failed to initialize provider", common.ErrorMsgProviderInitFailed)
+
+		return
+	}
+
+	ds.client = client
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (ds *ibNetworkSelectionDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_ib_network_selection"
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (ds *ibNetworkSelectionDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: providerDescSelectionDataSource,
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: providerDescProjectID,
+			},
+			"location": schema.StringAttribute{
+				Required:    true,
+				Description: providerDescSelectionLocation,
+			},
+			"slice_type": schema.StringAttribute{
+				Required:    true,
+				Description: providerDescSelectionSliceType,
+			},
+			"count": schema.Int64Attribute{
+				Required:    true,
+				Description: providerDescSelectionCount,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"policy": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: providerDescSelectionPolicy,
+				Validators: []validator.String{
+					stringvalidator.OneOf(selectionPolicySmallestSufficient, selectionPolicyMostFree),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: providerDescSelectionID,
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: providerDescSelectionName,
+			},
+			"available": schema.Int64Attribute{
+				Computed:    true,
+				Description: providerDescSelectionAvailable,
+			},
+		},
+	}
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (ds *ibNetworkSelectionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ibNetworkSelectionDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := common.GetProjectIDOrFallback(ds.client, config.ProjectID.ValueString())
+	policy := config.Policy.ValueString()
+	if policy == "" {
+		policy = selectionPolicySmallestSufficient
+	}
+
+	dataResp, httpResp, err := ds.client.APIClient.IBNetworksApi.ListIBNetworks(ctx, projectID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Fetch IB Networks",
+			fmt.Sprintf("Could not fetch Infiniband network data at this time: %s", common.UnpackAPIError(err)))
+
+		return
+	}
+
+	network, available, err := selectIBNetwork(dataResp.Items, config.Location.ValueString(), config.SliceType.ValueString(),
+		config.Count.ValueInt64(), policy)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to select IB Network", fmt.Sprintf("%s.", err))
+
+		return
+	}
+
+	config.ProjectID = types.StringValue(projectID)
+	config.Policy = types.StringValue(policy)
+	config.ID = types.StringValue(network.Id)
+	config.Name = types.StringValue(network.Name)
+	config.Available = types.Int64Value(available)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// selectIBNetwork returns the network in the location with room for count VMs of sliceType, chosen
+// by policy, along with its free slices for that type. When no network fits, the error lists what
+// each candidate has so the shortfall is clear.
+func selectIBNetwork(networks []swagger.IbNetwork, location, sliceType string, count int64, policy string,
+) (*swagger.IbNetwork, int64, error) {
+	var (
+		best          *swagger.IbNetwork
+		bestAvailable int64
+		shortfalls    []string
+	)
+
+	required := count * slicesPerInstance(sliceType)
+
+	for i := range networks {
+		network := &networks[i]
+		if network.Location != location {
+			continue
+		}
+
+		available, _ := availableCapacity(network.Capacities, sliceType)
+		if available < required {
+			shortfalls = append(shortfalls, fmt.Sprintf("%s (%s) has %d", network.Name, network.Id, available))
+
+			continue
+		}
+
+		if best == nil || betterFit(available, bestAvailable, policy) ||
+			(available == bestAvailable && sortsBefore(network, best)) {
+			best = network
+			bestAvailable = available
+		}
+	}
+
+	if best != nil {
+		return best, bestAvailable, nil
+	}
+
+	if len(shortfalls) == 0 {
+		return nil, 0, fmt.Errorf("no IB networks were found in location %s", location)
+	}
+
+	return nil, 0, fmt.Errorf("no IB network in location %s has capacity for %d %s VMs, %d slices: %s",
+		location, count, sliceType, required, strings.Join(shortfalls, ", "))
+}
+
+// betterFit reports whether a network with the candidate capacity is strictly preferred over one
+// with the current capacity under the policy.
+func betterFit(candidate, current int64, policy string) bool {
+	if policy == selectionPolicyMostFree {
+		return candidate > current
+	}
+
+	return candidate < current
+}
+
+// sortsBefore orders networks by name, then ID, to break ties deterministically.
+func sortsBefore(a, b *swagger.IbNetwork) bool {
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+
+	return a.Id < b.Id
+}
@@ -0,0 +1,66 @@
+package ib_network
+
+import (
+	"strings"
+	"testing"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+)
+
+func TestSelectIBNetwork(t *testing.T) {
+	const sliceType = "h100-80gb-sxm-ib.8x"
+	networks := []swagger.IbNetwork{
+		{Id: "ib-large", Name: "large", Location: "us-east1-a", Capacities: []swagger.IbNetworkCapacity{{Quantity: 64, SliceType: sliceType}}},
+		{Id: "ib-small", Name: "small", Location: "us-east1-a", Capacities: []swagger.IbNetworkCapacity{{Quantity: 16, SliceType: sliceType}}},
+		{Id: "ib-tiny", Name: "tiny", Location: "us-east1-a", Capacities: []swagger.IbNetworkCapacity{{Quantity: 8, SliceType: sliceType}}},
+		{Id: "ib-other", Name: "other", Location: "us-southcentral1-a", Capacities: []swagger.IbNetworkCapacity{{Quantity: 128, SliceType: sliceType}}},
+		{Id: "ib-twin", Name: "a-twin", Location: "us-east1-a", Capacities: []swagger.IbNetworkCapacity{{Quantity: 16, SliceType: sliceType}}},
+	}
+
+	tests := []struct {
+		name          string
+		count         int64
+		policy        string
+		wantID        string
+		wantAvailable int64
+	}{
+		{name: "smallest sufficient", count: 2, policy: selectionPolicySmallestSufficient, wantID: "ib-twin", wantAvailable: 16},
+		{name: "smallest sufficient exact fit", count: 1, policy: selectionPolicySmallestSufficient, wantID: "ib-tiny", wantAvailable: 8},
+		{name: "most free", count: 2, policy: selectionPolicyMostFree, wantID: "ib-large", wantAvailable: 64},
+		{name: "only one fits", count: 4, policy: selectionPolicySmallestSufficient, wantID: "ib-large", wantAvailable: 64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, available, err := selectIBNetwork(networks, "us-east1-a", sliceType, tt.count, tt.policy)
+			if err != nil {
+				t.Fatalf("selectIBNetwork() error = %v", err)
+			}
+			if got.Id != tt.wantID || available != tt.wantAvailable {
+				t.Errorf("selectIBNetwork() = (%s, %d), want (%s, %d)", got.Id, available, tt.wantID, tt.wantAvailable)
+			}
+		})
+	}
+}
+
+func TestSelectIBNetworkShortfall(t *testing.T) {
+	networks := []swagger.IbNetwork{
+		{Id: "ib-1", Name: "one", Location: "us-east1-a", Capacities: []swagger.IbNetworkCapacity{{Quantity: 16, SliceType: "h100-80gb-sxm-ib.8x"}}},
+		{Id: "ib-2", Name: "two", Location: "us-east1-a"},
+	}
+
+	_, _, err := selectIBNetwork(networks, "us-east1-a", "h100-80gb-sxm-ib.8x", 3, selectionPolicySmallestSufficient)
+	if err == nil {
+		t.Fatal("expected an error when no network fits")
+	}
+	for _, want := range []string{"one (ib-1) has 16", "two (ib-2) has 0", "3 h100-80gb-sxm-ib.8x VMs, 24 slices"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	_, _, err = selectIBNetwork(networks, "eu-iceland1-a", "h100-80gb-sxm-ib.8x", 1, selectionPolicySmallestSufficient)
+	if err == nil || !strings.Contains(err.Error(), "no IB networks were found") {
+		t.Errorf("expected a no networks error, got %v", err)
+	}
+}
@@ -14,7 +14,24 @@ const (
 	apiDescCapacitySliceType = "VM slice type the capacity applies to."
 )
 
+// Selection policies for the crusoe_ib_network_selection data source.
+const (
+	selectionPolicySmallestSufficient = "smallest_sufficient"
+	selectionPolicyMostFree           = "most_free"
+)
+
 // providerDesc* — provider-specific schema descriptions (Terraform-side; not from the spec).
 const (
 	providerDescProjectID = "ID of the project the InfiniBand network belongs to. " + project.ProviderDescProjectIDFallback
+
+	providerDescSelectionDataSource = "Selects the InfiniBand network in a location that best fits the requested number of VMs of one type."
+	providerDescSelectionLocation   = "Location to select an InfiniBand network in."
+	providerDescSelectionSliceType  = "VM instance type the network must have capacity for, which is also its slice type in the `capacities` of the `crusoe_ib_networks` data source."
+	providerDescSelectionCount      = "Number of VMs of the given type the network must have capacity for. Each VM takes the number of slices in the `.<n>x` suffix of its type, or one slice when there is none."
+	providerDescSelectionPolicy     = "How to choose among networks with enough capacity: `" + selectionPolicySmallestSufficient +
+		"` (default) picks the one with the least capacity that still fits, keeping larger networks free for larger requests; `" +
+		selectionPolicyMostFree + "` picks the one with the most capacity, leaving the most room to grow. Ties are broken by name."
+	providerDescSelectionID        = "ID of the selected InfiniBand network."
+	providerDescSelectionName      = "Name of the selected InfiniBand network."
+	providerDescSelectionAvailable = "Number of slices of the given type which are free on the selected network."
 )
@@ -0,0 +1,114 @@
+//nolint:gocritic // Implements Terraform defined interface
+package ib_partition
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+type ibPartitionsDataSource struct {
+	client *common.CrusoeClient
+}
+
+type ibPartitionsDataSourceModel struct {
+	ProjectID    types.String       `tfsdk:"project_id"`
+	IBNetworkID  types.String       `tfsdk:"ib_network_id"`
+	IBPartitions []ibPartitionModel `tfsdk:"ib_partitions"`
+}
+
+type ibPartitionModel struct {
+	ID          string `tfsdk:"id"`
+	Name        string `tfsdk:"name"`
+	IBNetworkID string `tfsdk:"ib_network_id"`
+}
+
+func NewIBPartitionsDataSource() datasource.DataSource {
+	return &ibPartitionsDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (ds *ibPartitionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*common.CrusoeClient)
+	if !ok {
+		resp.Diagnostics.AddError("Failed to initialize provider", common.ErrorMsgProviderInitFailed)
+
+		return
+	}
+
+	ds.client = client
+}
+
+func (ds *ibPartitionsDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_ib_partitions"
+}
+
+func (ds *ibPartitionsDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{Attributes: map[string]schema.Attribute{
+		"project_id": schema.StringAttribute{
+			Optional:    true,
+			Description: providerDescPartitionsProjectID,
+		},
+		"ib_network_id": schema.StringAttribute{
+			Optional:    true,
+			Description: providerDescPartitionsIBNetworkID,
+		},
+		"ib_partitions": schema.ListNestedAttribute{
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed:    true,
+						Description: apiDescID,
+					},
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: apiDescName,
+					},
+					"ib_network_id": schema.StringAttribute{
+						Computed:    true,
+						Description: apiDescIBNetworkID,
+					},
+				},
+			},
+		},
+	}}
+}
+
+func (ds *ibPartitionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ibPartitionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := common.GetProjectIDOrFallback(ds.client, config.ProjectID.ValueString())
+
+	dataResp, httpResp, err := ds.client.APIClient.IBPartitionsApi.ListIBPartitions(ctx, projectID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Fetch IB Partitions",
+			fmt.Sprintf("Could not fetch Infiniband partition data at this time: %s", common.UnpackAPIError(err)))
+
+		return
+	}
+
+	state := ibPartitionsDataSourceModel{
+		ProjectID:    config.ProjectID,
+		IBNetworkID:  config.IBNetworkID,
+		IBPartitions: filterIbPartitions(dataResp.Items, config.IBNetworkID.ValueString()),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -20,6 +20,9 @@ const (
 // providerDesc* — provider-specific schema descriptions (Terraform-side; not from the spec).
 const (
 	providerDescProjectID = "ID of the project the InfiniBand partition belongs to. " + project.ProviderDescProjectIDFallback
+
+	providerDescPartitionsProjectID   = "ID of the project to list InfiniBand partitions in. " + project.ProviderDescProjectIDFallback
+	providerDescPartitionsIBNetworkID = "If set, only partitions in this InfiniBand network are returned."
 )
 
 func findIbPartition(ctx context.Context, client *swagger.APIClient, ibPartitionID string) (*swagger.IbPartition, string, error) {
@@ -39,3 +42,26 @@ func ibPartitionToTerraformResourceModel(ibPartition *swagger.IbPartition, state
 	state.Name = types.StringValue(ibPartition.Name)
 	state.IBNetworkID = types.StringValue(ibPartition.IbNetworkId)
 }
+
+// filterIbPartitions converts the partitions in the given IB network, or all of them when
+// ibNetworkID is empty, to data source models sorted by name.
+func filterIbPartitions(partitions []swagger.IbPartition, ibNetworkID string) []ibPartitionModel {
+	models := make([]ibPartitionModel, 0, len(partitions))
+	for i := range partitions {
+		if ibNetworkID != "" && partitions[i].IbNetworkId != ibNetworkID {
+			continue
+		}
+		models = append(models, ibPartitionModel{
+			ID:          partitions[i].Id,
+			Name:        partitions[i].Name,
+			IBNetworkID: partitions[i].IbNetworkId,
+		})
+	}
+
+	common.SortByKeys(models,
+		func(p ibPartitionModel) string { return p.Name },
+		func(p ibPartitionModel) string { return p.ID },
+	)
+
+	return models
+}
@@ -40,3 +40,27 @@ func Test_ibPartitionToTerraformResourceModel(t *testing.T) {
 		t.Errorf("project_id = %q, want %q (untouched by transform)", got, "project-1")
 	}
 }
+
+// Test_filterIbPartitions verifies the network filter and that the result is sorted by name, so
+// repeated reads don't reorder the list.
+func Test_filterIbPartitions(t *testing.T) {
+	partitions := []swagger.IbPartition{
+		{Id: "ibp-3", Name: "zeta", IbNetworkId: "net-a"},
+		{Id: "ibp-1", Name: "alpha", IbNetworkId: "net-b"},
+		{Id: "ibp-2", Name: "beta", IbNetworkId: "net-a"},
+	}
+
+	all := filterIbPartitions(partitions, "")
+	if len(all) != 3 || all[0].ID != "ibp-1" || all[1].ID != "ibp-2" || all[2].ID != "ibp-3" {
+		t.Errorf("filterIbPartitions(all) = %+v, want sorted by name", all)
+	}
+
+	inNetwork := filterIbPartitions(partitions, "net-a")
+	if len(inNetwork) != 2 || inNetwork[0].Name != "beta" || inNetwork[1].Name != "zeta" {
+		t.Errorf("filterIbPartitions(net-a) = %+v, want beta and zeta", inNetwork)
+	}
+
+	if none := filterIbPartitions(partitions, "net-c"); len(none) != 0 {
+		t.Errorf("filterIbPartitions(net-c) = %+v, want none", none)
+	}
+}
@@ -4,23 +4,37 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
 	swagger "github.com/crusoecloud/client-go/swagger/v1"
 	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
 )
 
+var (
+	_ resource.Resource                   = &instanceGroupResource{}
+	_ resource.ResourceWithImportState    = &instanceGroupResource{}
+	_ resource.ResourceWithModifyPlan     = &instanceGroupResource{}
+	_ resource.ResourceWithUpgradeState   = &instanceGroupResource{}
+	_ resource.ResourceWithValidateConfig = &instanceGroupResource{}
+)
+
 type instanceGroupResource struct {
 	client *common.CrusoeClient
 }
@@ -37,6 +51,10 @@ type instanceGroupResourceModel struct {
 	State                types.String `tfsdk:"state"`
 	CreatedAt            types.String `tfsdk:"created_at"`
 	UpdatedAt            types.String `tfsdk:"updated_at"`
+	DeleteConcurrency    types.Int64  `tfsdk:"delete_concurrency"`
+	RollingUpdate        types.Object `tfsdk:"rolling_update"`
+	PendingInstanceIDs   types.List   `tfsdk:"pending_replacement_instance_ids"`
+	WaitFor              types.Object `tfsdk:"wait_for"`
 }
 
 func NewInstanceGroupResource() resource.Resource {
@@ -142,7 +160,110 @@ func (r *instanceGroupResource) Schema(ctx context.Context, req resource.SchemaR
 				Computed:            true,
 				MarkdownDescription: apiDescUpdatedAt,
 			},
+			"pending_replacement_instance_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: providerDescPendingInstanceIDs,
+			},
+			"delete_concurrency": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: providerDescDeleteConcurrency,
+				Default:             int64default.StaticInt64(defaultDeleteConcurrency),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
+		Blocks: map[string]schema.Block{
+			"rolling_update": rollingUpdateSchemaBlock(),
+			"wait_for":       waitForSchemaBlock(),
+		},
+	}
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (r *instanceGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var rollingUpdateObj types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rolling_update"), &rollingUpdateObj)...)
+	if resp.Diagnostics.HasError() || rollingUpdateObj.IsNull() || rollingUpdateObj.IsUnknown() {
+		return
+	}
+
+	var rollingUpdate rollingUpdateResourceModel
+	resp.Diagnostics.Append(rollingUpdateObj.As(ctx, &rollingUpdate, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if rollingUpdate.MaxUnavailable.IsUnknown() || rollingUpdate.MaxSurge.IsUnknown() {
+		return
+	}
+
+	maxUnavailable := int64(rollingUpdateDefaultMaxUnavailable)
+	if !rollingUpdate.MaxUnavailable.IsNull() {
+		maxUnavailable = rollingUpdate.MaxUnavailable.ValueInt64()
+	}
+	if maxUnavailable+rollingUpdate.MaxSurge.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("rolling_update"), "Invalid rolling update",
+			"At least one of max_unavailable and max_surge must be greater than 0, otherwise no instance can be replaced.")
+	}
+}
+
+// ModifyPlan plans pending_replacement_instance_ids, so a rolling update which stopped early is
+// resumed by the next apply, leaves the group's instances unknown when a rolling update or
+// wait_for will change them, and warns when a template change leaves existing instances on the previous
+// template.
+//
+//nolint:gocritic // Implements Terraform defined interface
+func (r *instanceGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state instanceGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templateChanged := !plan.InstanceTemplateID.Equal(state.InstanceTemplateID)
+	pending := len(state.PendingInstanceIDs.Elements()) > 0
+
+	// A rolling update may stop early again, so its outcome is only known after apply. Removing
+	// the rolling_update block drops the pending instances.
+	rollingUpdate := !plan.RollingUpdate.IsNull() && (templateChanged || pending)
+	planned := state.PendingInstanceIDs
+	switch {
+	case rollingUpdate:
+		planned = types.ListUnknown(types.StringType)
+	case pending:
+		planned = types.ListValueMust(types.StringType, nil)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("pending_replacement_instance_ids"), planned)...)
+
+	// A rolling update swaps the group's instances, and wait_for records them once a resize or
+	// template change has converged, so the instances planned from state by UseStateForUnknown
+	// would not match the group written to state after apply.
+	waitFor := !plan.WaitFor.IsNull() && (templateChanged || !plan.DesiredCount.Equal(state.DesiredCount))
+	if rollingUpdate || waitFor {
+		planInstancesUnknown(ctx, &resp.Plan, &resp.Diagnostics)
+	}
+
+	if !plan.RollingUpdate.IsNull() {
+		return
+	}
+
+	if templateChanged {
+		resp.Diagnostics.AddAttributeWarning(path.Root("instance_template_id"), "Existing instances will not be replaced",
+			"Existing instances keep running the previous template; only new instances will use the new one. "+
+				"Add a rolling_update block to replace existing instances batch by batch.")
+	} else if pending {
+		pendingInstanceIDs, _ := common.TFListToStringSlice(state.PendingInstanceIDs)
+		resp.Diagnostics.AddAttributeWarning(path.Root("rolling_update"), "Stopped rolling update will not be resumed",
+			fmt.Sprintf("Instances %s were not replaced by an earlier rolling update and will keep running the previous template. "+
+				"Keep the rolling_update block to resume replacing them.", formatIDs(pendingInstanceIDs)))
 	}
 }
 
@@ -167,6 +288,13 @@ func (r *instanceGroupResource) Create(ctx context.Context, req resource.CreateR
 
 	projectID := common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString())
 
+	waitTimeout, err := parseWaitForTimeout(ctx, plan.WaitFor)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create Instance Group", err.Error())
+
+		return
+	}
+
 	dataResp, httpResp, err := r.client.APIClient.InstanceGroupsApi.CreateInstanceGroup(ctx, swagger.InstanceGroupPostRequest{
 		Name:         plan.Name.ValueString(),
 		TemplateId:   plan.InstanceTemplateID.ValueString(),
@@ -190,6 +318,16 @@ func (r *instanceGroupResource) Create(ctx context.Context, req resource.CreateR
 
 	var state instanceGroupResourceModel
 	instanceGroupToResourceModel(&dataResp, &state, &resp.Diagnostics)
+	state.RollingUpdate = plan.RollingUpdate
+	state.PendingInstanceIDs = types.ListValueMust(types.StringType, nil)
+	state.WaitFor = plan.WaitFor
+	state.DeleteConcurrency = plan.DeleteConcurrency
+
+	if waitTimeout > 0 {
+		// A failed create would taint the group and replace every instance on the next apply, so a
+		// timeout only warns here.
+		r.awaitDesiredCountIntoState(ctx, state.ProjectID.ValueString(), state.ID.ValueString(), waitTimeout, &state, &resp.Diagnostics, false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
@@ -222,6 +360,10 @@ func (r *instanceGroupResource) Read(ctx context.Context, req resource.ReadReque
 	}
 
 	instanceGroupToResourceModel(&instanceGroup, &state, &resp.Diagnostics)
+	if state.DeleteConcurrency.IsNull() {
+		// Not set after an import
+		state.DeleteConcurrency = types.Int64Value(defaultDeleteConcurrency)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -238,9 +380,65 @@ func (r *instanceGroupResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	projectID := plan.ProjectID.ValueString()
+	instanceGroupID := plan.ID.ValueString()
+
+	waitTimeout, err := parseWaitForTimeout(ctx, plan.WaitFor)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update Instance Group", err.Error())
+
+		return
+	}
+
+	// A rolling update replaces the instances that exist before the template changes, or resumes
+	// with the instances an earlier one didn't get to. max_surge extra instances are requested up
+	// front so they start before the first batch is deleted.
+	var rollingUpdate *rollingUpdateOptions
+	var oldInstanceIDs []string
+	pendingInstanceIDs, err := common.TFListToStringSlice(state.PendingInstanceIDs)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update Instance Group", fmt.Sprintf("Could not read pending_replacement_instance_ids: %s", err))
+
+		return
+	}
+	templateChanged := !plan.InstanceTemplateID.Equal(state.InstanceTemplateID)
+	if templateChanged || len(pendingInstanceIDs) > 0 {
+		rollingUpdate, err = parseRollingUpdate(ctx, plan.RollingUpdate)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update Instance Group", err.Error())
+
+			return
+		}
+	}
+	if rollingUpdate != nil {
+		current, getHttpResp, err := r.client.APIClient.InstanceGroupsApi.GetInstanceGroup(ctx, instanceGroupID, projectID)
+		if getHttpResp != nil {
+			defer getHttpResp.Body.Close()
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to update Instance Group",
+				fmt.Sprintf("Could not retrieve the instance group's current instances: %s", common.UnpackAPIError(err)),
+			)
+
+			return
+		}
+
+		oldInstanceIDs = append(slices.Clone(current.InactiveInstances), current.ActiveInstances...)
+		if !templateChanged {
+			oldInstanceIDs = remainingInstanceIDs(pendingInstanceIDs, oldInstanceIDs)
+		}
+		if len(oldInstanceIDs) == 0 {
+			rollingUpdate = nil
+		}
+	}
+
 	desiredCount := swagger.DesiredCount{
 		Value: plan.DesiredCount.ValueInt64(),
 	}
+	if rollingUpdate != nil {
+		desiredCount.Value += rollingUpdate.MaxSurge
+	}
 
 	dataResp, httpResp, err := r.client.APIClient.InstanceGroupsApi.PatchInstanceGroup(ctx,
 		swagger.InstanceGroupPatchRequest{
@@ -248,8 +446,8 @@ func (r *instanceGroupResource) Update(ctx context.Context, req resource.UpdateR
 			TemplateId:   plan.InstanceTemplateID.ValueString(),
 			DesiredCount: &desiredCount,
 		},
-		plan.ID.ValueString(),
-		plan.ProjectID.ValueString(),
+		instanceGroupID,
+		projectID,
 	)
 	if httpResp != nil {
 		defer httpResp.Body.Close()
@@ -268,10 +466,72 @@ func (r *instanceGroupResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	instanceGroupToResourceModel(&dataResp, &state, &resp.Diagnostics)
+	state.RollingUpdate = plan.RollingUpdate
+	state.PendingInstanceIDs = plan.PendingInstanceIDs
+	if state.PendingInstanceIDs.IsUnknown() {
+		state.PendingInstanceIDs = types.ListValueMust(types.StringType, nil)
+	}
+	state.WaitFor = plan.WaitFor
+	state.DeleteConcurrency = plan.DeleteConcurrency
+
+	if rollingUpdate != nil {
+		group, pending, err := r.rollingReplace(ctx, projectID, instanceGroupID, plan.DesiredCount.ValueInt64(), oldInstanceIDs, rollingUpdate)
+		if group != nil {
+			instanceGroupToResourceModel(group, &state, &resp.Diagnostics)
+		}
+		// The instances which weren't replaced are kept in state, so the next apply resumes with them.
+		var listDiags diag.Diagnostics
+		state.PendingInstanceIDs, listDiags = common.StringSliceToTFList(pending)
+		resp.Diagnostics.Append(listDiags...)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to replace Instance Group instances",
+				err.Error()+"\n\nApply again to resume the rolling update with the instances which were not replaced.")
+			resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+			return
+		}
+	}
+
+	if waitTimeout > 0 {
+		r.awaitDesiredCountIntoState(ctx, projectID, instanceGroupID, waitTimeout, &state, &resp.Diagnostics, true)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
+// awaitDesiredCountIntoState waits for the group to converge on desired_count and records the
+// last observed group in state. If it did not converge in time, it adds an error, or a warning
+// when failOnTimeout is false.
+func (r *instanceGroupResource) awaitDesiredCountIntoState(ctx context.Context, projectID, instanceGroupID string, timeout time.Duration,
+	state *instanceGroupResourceModel, diags *diag.Diagnostics, failOnTimeout bool,
+) {
+	group, err := r.awaitDesiredCount(ctx, projectID, instanceGroupID, timeout)
+	if group != nil {
+		instanceGroupToResourceModel(group, state, diags)
+	}
+	if err == nil {
+		return
+	}
+
+	if failOnTimeout {
+		diags.AddError("Instance Group did not reach desired_count",
+			fmt.Sprintf("The instance group was applied, but not all of its instances became active: %s", err))
+	} else {
+		diags.AddWarning("Instance Group did not reach desired_count",
+			fmt.Sprintf("The instance group was created, but not all of its instances became active: %s\n\n"+
+				"The instance group is kept, and resources which depend on it are applied anyway.", err))
+	}
+}
+
+// planInstancesUnknown marks the attributes which describe the group's instances as unknown, for
+// updates which change the instances before they return.
+func planInstancesUnknown(ctx context.Context, plan *tfsdk.Plan, diags *diag.Diagnostics) {
+	diags.Append(plan.SetAttribute(ctx, path.Root("running_instance_count"), types.Int64Unknown())...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("active_instance_ids"), types.ListUnknown(types.StringType))...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("inactive_instance_ids"), types.ListUnknown(types.StringType))...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("state"), types.StringUnknown())...)
+}
+
 //nolint:gocritic // Implements Terraform defined interface
 func (r *instanceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state instanceGroupResourceModel
@@ -294,6 +554,10 @@ func (r *instanceGroupResource) Delete(ctx context.Context, req resource.DeleteR
 	if patchHttpResp != nil {
 		defer patchHttpResp.Body.Close()
 	}
+	if patchHttpResp != nil && patchHttpResp.StatusCode == http.StatusNotFound {
+		// The group is already gone, most likely deleted by an earlier, interrupted destroy
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to update Instance Group before deletion",
@@ -307,11 +571,14 @@ func (r *instanceGroupResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	// Step 2: Get current instance group state to retrieve active instance IDs
+	// Step 2: Get current instance group state to retrieve its instance IDs
 	instanceGroup, getHttpResp, err := r.client.APIClient.InstanceGroupsApi.GetInstanceGroup(ctx, instanceGroupID, projectID)
 	if getHttpResp != nil {
 		defer getHttpResp.Body.Close()
 	}
+	if getHttpResp != nil && getHttpResp.StatusCode == http.StatusNotFound {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to get Instance Group",
@@ -325,59 +592,18 @@ func (r *instanceGroupResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	// Step 3: Delete active instances
-	instanceErrors := make(map[string]string) // instanceID -> error message
-	for _, instanceID := range instanceGroup.ActiveInstances {
-		delDataResp, delHttpResp, delErr := r.client.APIClient.VMsApi.DeleteInstance(ctx, projectID, instanceID)
-		if delErr != nil {
-			if delHttpResp != nil {
-				delHttpResp.Body.Close()
-			}
-			instanceErrors[instanceID] = common.UnpackAPIError(delErr).Error()
-
-			continue
-		}
-
-		// Wait for the delete operation to complete
-		_, _, waitErr := common.AwaitOperationAndResolve[interface{}](ctx, delDataResp.Operation, projectID,
-			r.client.APIClient.VMOperationsApi.GetComputeVMsInstancesOperation)
-		if delHttpResp != nil {
-			delHttpResp.Body.Close()
-		}
-		if waitErr != nil {
-			instanceErrors[instanceID] = common.UnpackAPIError(waitErr).Error()
-
-			continue
-		}
+	// Step 3: Delete the instances concurrently. Inactive instances are deleted too, and instances
+	// which are already gone count as deleted, so re-running after a partial failure resumes cleanly.
+	instanceIDs := append(slices.Clone(instanceGroup.ActiveInstances), instanceGroup.InactiveInstances...)
+	concurrency := int64(defaultDeleteConcurrency)
+	if !state.DeleteConcurrency.IsNull() && !state.DeleteConcurrency.IsUnknown() {
+		concurrency = state.DeleteConcurrency.ValueInt64()
 	}
 
-	if len(instanceErrors) > 0 {
-		// Check if all errors are the same
-		var firstErr string
-		allSame := true
-		var failedIDs []string
-		for id, errMsg := range instanceErrors {
-			failedIDs = append(failedIDs, id)
-			if firstErr == "" {
-				firstErr = errMsg
-			} else if errMsg != firstErr {
-				allSame = false
-
-				break
-			}
-		}
-
-		var errDetail string
-		if allSame {
-			errDetail = fmt.Sprintf("Could not delete instances %v: %s", failedIDs, firstErr)
-		} else {
-			errDetail = "Could not delete instances:"
-			for id, errMsg := range instanceErrors {
-				errDetail += fmt.Sprintf("\n  %s: %s", id, errMsg)
-			}
-		}
-
-		resp.Diagnostics.AddError("Failed to delete instances", errDetail)
+	if instanceErrors := r.deleteGroupInstances(ctx, projectID, instanceIDs, concurrency); len(instanceErrors) > 0 {
+		resp.Diagnostics.AddError("Failed to delete instances",
+			fmt.Sprintf("%s\n\n%d of %d instances were deleted. Destroying again retries the remaining instances.",
+				formatInstanceErrors(instanceErrors), len(instanceIDs)-len(instanceErrors), len(instanceIDs)))
 
 		return
 	}
@@ -400,3 +626,45 @@ func (r *instanceGroupResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 }
+
+// patchDesiredCount sets the group's desired_count, leaving everything else unchanged.
+func (r *instanceGroupResource) patchDesiredCount(ctx context.Context, projectID, instanceGroupID string, count int64) error {
+	_, httpResp, err := r.client.APIClient.InstanceGroupsApi.PatchInstanceGroup(ctx,
+		swagger.InstanceGroupPatchRequest{
+			DesiredCount: &swagger.DesiredCount{Value: count},
+		},
+		instanceGroupID,
+		projectID,
+	)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return common.UnpackAPIError(err)
+	}
+
+	return nil
+}
+
+// deleteGroupInstance deletes one of the group's instances and waits for the deletion to complete.
+// An instance which is already gone counts as deleted.
+func (r *instanceGroupResource) deleteGroupInstance(ctx context.Context, projectID, instanceID string) error {
+	dataResp, httpResp, err := r.client.APIClient.VMsApi.DeleteInstance(ctx, projectID, instanceID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return common.UnpackAPIError(err)
+	}
+
+	_, _, err = common.AwaitOperationAndResolve[interface{}](ctx, dataResp.Operation, projectID,
+		r.client.APIClient.VMOperationsApi.GetComputeVMsInstancesOperation)
+	if err != nil {
+		return common.UnpackAPIError(err)
+	}
+
+	return nil
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestInstanceGroupResource_Metadata(t *testing.T) {
@@ -22,3 +27,151 @@ func TestInstanceGroupResource_Metadata(t *testing.T) {
 		t.Errorf("TypeName: expected %q, got %q", expected, resp.TypeName)
 	}
 }
+
+// instanceGroupTestModel is a healthy group of two instances running tmpl-1.
+func instanceGroupTestModel() instanceGroupResourceModel {
+	return instanceGroupResourceModel{
+		ID:                   types.StringValue("ig-1"),
+		Name:                 types.StringValue("workers"),
+		InstanceTemplateID:   types.StringValue("tmpl-1"),
+		RunningInstanceCount: types.Int64Value(2),
+		ActiveInstanceIDs:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("vm-1"), types.StringValue("vm-2")}),
+		InactiveInstanceIDs:  types.ListValueMust(types.StringType, nil),
+		ProjectID:            types.StringValue("proj-1"),
+		DesiredCount:         types.Int64Value(2),
+		State:                types.StringValue("HEALTHY"),
+		CreatedAt:            types.StringValue("2024-01-01T00:00:00Z"),
+		UpdatedAt:            types.StringValue("2024-01-01T00:00:00Z"),
+		DeleteConcurrency:    types.Int64Value(defaultDeleteConcurrency),
+		RollingUpdate:        types.ObjectNull(rollingUpdateSchema.AttrTypes),
+		PendingInstanceIDs:   types.ListValueMust(types.StringType, nil),
+		WaitFor:              types.ObjectNull(waitForSchema.AttrTypes),
+	}
+}
+
+func instanceGroupTestSchema(ctx context.Context) schema.Schema {
+	resp := &resource.SchemaResponse{}
+	NewInstanceGroupResource().Schema(ctx, resource.SchemaRequest{}, resp)
+
+	return resp.Schema
+}
+
+func TestInstanceGroupModifyPlanInstances(t *testing.T) {
+	ctx := context.Background()
+	s := instanceGroupTestSchema(ctx)
+
+	tests := []struct {
+		name        string
+		modifyState func(m *instanceGroupResourceModel)
+		modifyPlan  func(m *instanceGroupResourceModel)
+		wantUnknown bool
+	}{
+		{
+			name: "template change with rolling update",
+			modifyPlan: func(m *instanceGroupResourceModel) {
+				m.InstanceTemplateID = types.StringValue("tmpl-2")
+				m.RollingUpdate = rollingUpdateObject(t, 1, 0, "15m")
+			},
+			wantUnknown: true,
+		},
+		{
+			name: "resumed rolling update",
+			modifyState: func(m *instanceGroupResourceModel) {
+				m.PendingInstanceIDs = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("vm-2")})
+			},
+			modifyPlan: func(m *instanceGroupResourceModel) {
+				m.RollingUpdate = rollingUpdateObject(t, 1, 0, "15m")
+			},
+			wantUnknown: true,
+		},
+		{
+			name: "template change without rolling update",
+			modifyPlan: func(m *instanceGroupResourceModel) {
+				m.InstanceTemplateID = types.StringValue("tmpl-2")
+			},
+			wantUnknown: false,
+		},
+		{
+			name: "resize with wait_for",
+			modifyPlan: func(m *instanceGroupResourceModel) {
+				m.DesiredCount = types.Int64Value(4)
+				m.WaitFor = waitForObject(t, "20m")
+			},
+			wantUnknown: true,
+		},
+		{
+			name: "template change with wait_for",
+			modifyPlan: func(m *instanceGroupResourceModel) {
+				m.InstanceTemplateID = types.StringValue("tmpl-2")
+				m.WaitFor = waitForObject(t, "20m")
+			},
+			wantUnknown: true,
+		},
+		{
+			name: "rename with wait_for",
+			modifyPlan: func(m *instanceGroupResourceModel) {
+				m.Name = types.StringValue("renamed")
+				m.WaitFor = waitForObject(t, "20m")
+			},
+			wantUnknown: false,
+		},
+		{
+			name: "resize without wait_for",
+			modifyPlan: func(m *instanceGroupResourceModel) {
+				m.DesiredCount = types.Int64Value(4)
+			},
+			wantUnknown: false,
+		},
+		{
+			name: "rename with rolling update",
+			modifyPlan: func(m *instanceGroupResourceModel) {
+				m.Name = types.StringValue("renamed")
+				m.RollingUpdate = rollingUpdateObject(t, 1, 0, "15m")
+			},
+			wantUnknown: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateModel, planModel := instanceGroupTestModel(), instanceGroupTestModel()
+			if tt.modifyState != nil {
+				tt.modifyState(&stateModel)
+			}
+			// UseStateForUnknown has already copied the computed attributes from state.
+			planModel.PendingInstanceIDs = stateModel.PendingInstanceIDs
+			tt.modifyPlan(&planModel)
+
+			state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+			plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+			if diags := state.Set(ctx, &stateModel); diags.HasError() {
+				t.Fatalf("failed to build state: %v", diags)
+			}
+			if diags := plan.Set(ctx, &planModel); diags.HasError() {
+				t.Fatalf("failed to build plan: %v", diags)
+			}
+
+			req := resource.ModifyPlanRequest{State: state, Plan: plan}
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			(&instanceGroupResource{}).ModifyPlan(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("ModifyPlan() returned errors: %v", resp.Diagnostics)
+			}
+
+			var got instanceGroupResourceModel
+			if diags := resp.Plan.Get(ctx, &got); diags.HasError() {
+				t.Fatalf("failed to read plan: %v", diags)
+			}
+			for attrName, unknown := range map[string]bool{
+				"running_instance_count": got.RunningInstanceCount.IsUnknown(),
+				"active_instance_ids":    got.ActiveInstanceIDs.IsUnknown(),
+				"inactive_instance_ids":  got.InactiveInstanceIDs.IsUnknown(),
+				"state":                  got.State.IsUnknown(),
+			} {
+				if unknown != tt.wantUnknown {
+					t.Errorf("%s unknown = %v, want %v", attrName, unknown, tt.wantUnknown)
+				}
+			}
+		})
+	}
+}
@@ -78,6 +78,10 @@ func upgradeStateV0ToV1(ctx context.Context, req resource.UpgradeStateRequest, r
 		State:                types.StringNull(),
 		CreatedAt:            types.StringNull(),
 		UpdatedAt:            types.StringNull(),
+		DeleteConcurrency:    types.Int64Value(defaultDeleteConcurrency),
+		RollingUpdate:        types.ObjectNull(rollingUpdateSchema.AttrTypes),
+		PendingInstanceIDs:   types.ListNull(types.StringType),
+		WaitFor:              types.ObjectNull(waitForSchema.AttrTypes),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, newState)...)
@@ -0,0 +1,286 @@
+package instance_group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+	validators "github.com/crusoecloud/terraform-provider-crusoe/internal/validators"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/vm"
+)
+
+const (
+	rollingUpdateDefaultMaxUnavailable = 1
+	rollingUpdateDefaultMaxSurge       = 0
+	rollingUpdateDefaultBatchTimeout   = "15m"
+
+	instanceGroupPollInterval = 10 * time.Second
+)
+
+type rollingUpdateResourceModel struct {
+	MaxUnavailable types.Int64  `tfsdk:"max_unavailable"`
+	MaxSurge       types.Int64  `tfsdk:"max_surge"`
+	Port           types.Int64  `tfsdk:"port"`
+	AddressType    types.String `tfsdk:"address_type"`
+	BatchTimeout   types.String `tfsdk:"batch_timeout"`
+}
+
+var rollingUpdateSchema = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"max_unavailable": types.Int64Type,
+		"max_surge":       types.Int64Type,
+		"port":            types.Int64Type,
+		"address_type":    types.StringType,
+		"batch_timeout":   types.StringType,
+	},
+}
+
+// rollingUpdateOptions are the parsed rolling_update settings.
+type rollingUpdateOptions struct {
+	MaxUnavailable int64
+	MaxSurge       int64
+	Port           int64
+	AddressType    string
+	BatchTimeout   time.Duration
+}
+
+func rollingUpdateSchemaBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: providerDescRollingUpdate,
+		Attributes: map[string]schema.Attribute{
+			"max_unavailable": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: providerDescRollingUpdateMaxUnavailable,
+				Default:             int64default.StaticInt64(rollingUpdateDefaultMaxUnavailable),
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"max_surge": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: providerDescRollingUpdateMaxSurge,
+				Default:             int64default.StaticInt64(rollingUpdateDefaultMaxSurge),
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"port": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: providerDescRollingUpdatePort,
+				Validators:          []validator.Int64{int64validator.Between(1, 65535)},
+			},
+			"address_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: providerDescRollingUpdateAddressType,
+				Default:             stringdefault.StaticString(vm.WaitForAddressPublic),
+				Validators:          []validator.String{stringvalidator.OneOf(vm.WaitForAddressPublic, vm.WaitForAddressPrivate)},
+			},
+			"batch_timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: providerDescRollingUpdateBatchTimeout,
+				Default:             stringdefault.StaticString(rollingUpdateDefaultBatchTimeout),
+				Validators:          []validator.String{validators.DurationValidator{}},
+			},
+		},
+	}
+}
+
+// parseRollingUpdate reads the rolling_update block. It returns nil when the block is absent.
+func parseRollingUpdate(ctx context.Context, obj types.Object) (*rollingUpdateOptions, error) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+
+	var model rollingUpdateResourceModel
+	if diags := obj.As(ctx, &model, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return nil, errors.New("failed to read rolling_update settings")
+	}
+
+	timeout, err := time.ParseDuration(model.BatchTimeout.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("invalid rolling_update batch_timeout: %w", err)
+	}
+
+	opts := &rollingUpdateOptions{
+		MaxUnavailable: model.MaxUnavailable.ValueInt64(),
+		MaxSurge:       model.MaxSurge.ValueInt64(),
+		Port:           model.Port.ValueInt64(),
+		AddressType:    model.AddressType.ValueString(),
+		BatchTimeout:   timeout,
+	}
+	if opts.MaxUnavailable+opts.MaxSurge < 1 {
+		return nil, errors.New("at least one of rolling_update max_unavailable and max_surge must be greater than 0")
+	}
+
+	return opts, nil
+}
+
+// rollingUpdateBatches splits the instances to replace into batches of max_unavailable + max_surge,
+// so at most max_unavailable instances are missing while a batch is replaced.
+func rollingUpdateBatches(instanceIDs []string, opts *rollingUpdateOptions) [][]string {
+	size := int(opts.MaxUnavailable + opts.MaxSurge)
+
+	var batches [][]string
+	for batch := range slices.Chunk(instanceIDs, size) {
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
+
+// expectedNewInstances is how many instances from the new template the group should have once it
+// has converged on desiredCount, given that remaining instances from the old template still count
+// towards it.
+func expectedNewInstances(desiredCount int64, remainingOld int) int64 {
+	return max(desiredCount-int64(remainingOld), 0)
+}
+
+// rollingReplace replaces oldInstanceIDs, the group's instances from before the template change,
+// batch by batch. The group must already use the new template with desired_count raised by
+// max_surge; the surge is given back before the last batch. It returns the last observed group and,
+// when it stops early, the old instances which were not deleted.
+func (r *instanceGroupResource) rollingReplace(ctx context.Context, projectID, groupID string, desiredCount int64,
+	oldInstanceIDs []string, opts *rollingUpdateOptions,
+) (*swagger.InstanceGroup, []string, error) {
+	old := make(map[string]bool, len(oldInstanceIDs))
+	for _, id := range oldInstanceIDs {
+		old[id] = true
+	}
+
+	batches := rollingUpdateBatches(oldInstanceIDs, opts)
+	probed := make(map[string]bool)
+	target := desiredCount + opts.MaxSurge
+	remaining := len(oldInstanceIDs)
+
+	var group *swagger.InstanceGroup
+	pending := func() []string {
+		return oldInstanceIDs[len(oldInstanceIDs)-remaining:]
+	}
+	report := func(batch int, err error) error {
+		return fmt.Errorf("rolling update stopped at batch %d of %d: %w\n\nInstances replaced so far: %s\nInstances still on the previous template: %s",
+			batch, len(batches), err, formatIDs(oldInstanceIDs[:len(oldInstanceIDs)-remaining]), formatIDs(pending()))
+	}
+
+	for i, batch := range batches {
+		// Wait for the surge, or the previous batch's replacements, before taking more instances away.
+		var err error
+		group, err = r.awaitNewInstances(ctx, projectID, groupID, old, expectedNewInstances(target, remaining), opts, probed)
+		if err != nil {
+			return group, pending(), report(i+1, err)
+		}
+
+		if i == len(batches)-1 && target != desiredCount {
+			target = desiredCount
+			if err := r.patchDesiredCount(ctx, projectID, groupID, target); err != nil {
+				return group, pending(), report(i+1, fmt.Errorf("failed to restore desired_count to %d: %w", target, err))
+			}
+		}
+
+		for _, instanceID := range batch {
+			if err := r.deleteGroupInstance(ctx, projectID, instanceID); err != nil {
+				return group, pending(), report(i+1, fmt.Errorf("failed to delete instance %s: %w", instanceID, err))
+			}
+			remaining--
+		}
+	}
+
+	group, err := r.awaitNewInstances(ctx, projectID, groupID, old, expectedNewInstances(target, remaining), opts, probed)
+	if err != nil {
+		return group, pending(), report(len(batches), err)
+	}
+
+	return group, nil, nil
+}
+
+// awaitNewInstances polls the group until at least expected instances which aren't in old are
+// active and, when a port is configured, accept TCP connections on it.
+func (r *instanceGroupResource) awaitNewInstances(ctx context.Context, projectID, groupID string, old map[string]bool,
+	expected int64, opts *rollingUpdateOptions, probed map[string]bool,
+) (*swagger.InstanceGroup, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.BatchTimeout)
+	defer cancel()
+
+	var group *swagger.InstanceGroup
+	for {
+		current, httpResp, err := r.client.APIClient.InstanceGroupsApi.GetInstanceGroup(ctx, groupID, projectID)
+		if httpResp != nil {
+			httpResp.Body.Close()
+		}
+		if err == nil {
+			group = &current
+			active := newInstances(group.ActiveInstances, old)
+			if int64(len(active)) >= expected {
+				for _, instanceID := range active {
+					if opts.Port == 0 || probed[instanceID] {
+						continue
+					}
+					if err := vm.AwaitVMPort(ctx, r.client.APIClient, projectID, instanceID, opts.Port, opts.AddressType); err != nil {
+						return group, fmt.Errorf("instance %s did not pass the health probe within %s: %w", instanceID, opts.BatchTimeout, err)
+					}
+					probed[instanceID] = true
+				}
+
+				return group, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if group == nil {
+				return nil, fmt.Errorf("failed to get the instance group: %w", common.UnpackAPIError(err))
+			}
+
+			return group, fmt.Errorf("only %d of %d new instances became active within %s; new instances not yet active: %s",
+				len(newInstances(group.ActiveInstances, old)), expected, opts.BatchTimeout, formatIDs(newInstances(group.InactiveInstances, old)))
+		case <-time.After(instanceGroupPollInterval):
+		}
+	}
+}
+
+// newInstances returns the instance IDs which aren't in old.
+func newInstances(instanceIDs []string, old map[string]bool) []string {
+	var ids []string
+	for _, id := range instanceIDs {
+		if !old[id] {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// remainingInstanceIDs returns the pending instance IDs which are still in the group, in the
+// group's order. Instances removed outside of Terraform since the rollout stopped are dropped.
+func remainingInstanceIDs(pending, current []string) []string {
+	var ids []string
+	for _, id := range current {
+		if slices.Contains(pending, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+func formatIDs(ids []string) string {
+	if len(ids) == 0 {
+		return "none"
+	}
+
+	return strings.Join(ids, ", ")
+}
@@ -0,0 +1,108 @@
+package instance_group
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func rollingUpdateObject(t *testing.T, maxUnavailable, maxSurge int64, batchTimeout string) types.Object {
+	t.Helper()
+
+	obj, diags := types.ObjectValue(rollingUpdateSchema.AttrTypes, map[string]attr.Value{
+		"max_unavailable": types.Int64Value(maxUnavailable),
+		"max_surge":       types.Int64Value(maxSurge),
+		"port":            types.Int64Null(),
+		"address_type":    types.StringValue("public"),
+		"batch_timeout":   types.StringValue(batchTimeout),
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to build rolling_update object: %v", diags)
+	}
+
+	return obj
+}
+
+func TestParseRollingUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	opts, err := parseRollingUpdate(ctx, types.ObjectNull(rollingUpdateSchema.AttrTypes))
+	if err != nil || opts != nil {
+		t.Errorf("null block: got (%v, %v), want (nil, nil)", opts, err)
+	}
+
+	opts, err = parseRollingUpdate(ctx, rollingUpdateObject(t, 2, 1, "5m"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &rollingUpdateOptions{MaxUnavailable: 2, MaxSurge: 1, AddressType: "public", BatchTimeout: 5 * time.Minute}
+	if !reflect.DeepEqual(opts, want) {
+		t.Errorf("got %+v, want %+v", opts, want)
+	}
+
+	if _, err := parseRollingUpdate(ctx, rollingUpdateObject(t, 0, 0, "5m")); err == nil {
+		t.Error("expected an error when neither max_unavailable nor max_surge is positive")
+	}
+}
+
+func TestRollingUpdateBatches(t *testing.T) {
+	ids := []string{"vm-1", "vm-2", "vm-3", "vm-4", "vm-5"}
+
+	got := rollingUpdateBatches(ids, &rollingUpdateOptions{MaxUnavailable: 1, MaxSurge: 1})
+	want := [][]string{{"vm-1", "vm-2"}, {"vm-3", "vm-4"}, {"vm-5"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := rollingUpdateBatches(nil, &rollingUpdateOptions{MaxUnavailable: 1}); len(got) != 0 {
+		t.Errorf("no instances: got %v, want no batches", got)
+	}
+}
+
+// TestExpectedNewInstances walks through a rollout of 4 instances with max_unavailable = 1 and
+// max_surge = 1, where the surge is given back before the last batch.
+func TestExpectedNewInstances(t *testing.T) {
+	tests := []struct {
+		name         string
+		desiredCount int64
+		remainingOld int
+		want         int64
+	}{
+		{name: "surge before the first batch", desiredCount: 5, remainingOld: 4, want: 1},
+		{name: "after the first batch", desiredCount: 5, remainingOld: 2, want: 3},
+		{name: "after the last batch", desiredCount: 4, remainingOld: 0, want: 4},
+		{name: "more old instances than desired", desiredCount: 2, remainingOld: 4, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expectedNewInstances(tt.desiredCount, tt.remainingOld); got != tt.want {
+				t.Errorf("expectedNewInstances(%d, %d) = %d, want %d", tt.desiredCount, tt.remainingOld, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewInstances(t *testing.T) {
+	old := map[string]bool{"vm-1": true, "vm-2": true}
+
+	got := newInstances([]string{"vm-1", "vm-3", "vm-2", "vm-4"}, old)
+	if want := []string{"vm-3", "vm-4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRemainingInstanceIDs(t *testing.T) {
+	got := remainingInstanceIDs([]string{"vm-2", "vm-1", "vm-5"}, []string{"vm-1", "vm-2", "vm-3", "vm-4"})
+	if want := []string{"vm-1", "vm-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := remainingInstanceIDs(nil, []string{"vm-1"}); got != nil {
+		t.Errorf("got %v, want no instances", got)
+	}
+}
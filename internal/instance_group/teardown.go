@@ -0,0 +1,72 @@
+package instance_group
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const defaultDeleteConcurrency = 8
+
+// deleteGroupInstances deletes the instances with at most concurrency deletions in flight. A failed
+// deletion doesn't stop the others; the failures are returned by instance ID.
+func (r *instanceGroupResource) deleteGroupInstances(ctx context.Context, projectID string, instanceIDs []string, concurrency int64,
+) map[string]string {
+	var (
+		mu             sync.Mutex
+		wg             sync.WaitGroup
+		instanceErrors = make(map[string]string)
+		sem            = make(chan struct{}, max(concurrency, 1))
+	)
+
+	for _, instanceID := range instanceIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := r.deleteGroupInstance(ctx, projectID, instanceID); err != nil {
+				mu.Lock()
+				instanceErrors[instanceID] = err.Error()
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return instanceErrors
+}
+
+// formatInstanceErrors describes failed instance deletions, collapsing them into one line when
+// every instance failed with the same error.
+func formatInstanceErrors(instanceErrors map[string]string) string {
+	failedIDs := make([]string, 0, len(instanceErrors))
+	for id := range instanceErrors {
+		failedIDs = append(failedIDs, id)
+	}
+	slices.Sort(failedIDs)
+
+	allSame := true
+	for _, id := range failedIDs {
+		if instanceErrors[id] != instanceErrors[failedIDs[0]] {
+			allSame = false
+
+			break
+		}
+	}
+
+	if allSame {
+		return fmt.Sprintf("Could not delete instances %v: %s", failedIDs, instanceErrors[failedIDs[0]])
+	}
+
+	var b strings.Builder
+	b.WriteString("Could not delete instances:")
+	for _, id := range failedIDs {
+		fmt.Fprintf(&b, "\n  %s: %s", id, instanceErrors[id])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package instance_group
+
+import "testing"
+
+func TestFormatInstanceErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		instanceErrors map[string]string
+		want           string
+	}{
+		{
+			name:           "same error",
+			instanceErrors: map[string]string{"vm-2": "quota exceeded", "vm-1": "quota exceeded"},
+			want:           "Could not delete instances [vm-1 vm-2]: quota exceeded",
+		},
+		{
+			name:           "different errors",
+			instanceErrors: map[string]string{"vm-2": "timed out", "vm-1": "internal error"},
+			want:           "Could not delete instances:\n  vm-1: internal error\n  vm-2: timed out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatInstanceErrors(tt.instanceErrors); got != tt.want {
+				t.Errorf("formatInstanceErrors() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
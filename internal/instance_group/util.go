@@ -30,6 +30,30 @@ const (
 	providerDescProjectID      = "ID of the project that owns the instance group. " + project.ProviderDescProjectIDFallback
 	providerDescState          = "Possible values: `HEALTHY` (matches desired count), `UPDATING` (scaling in progress), `UNHEALTHY` (cannot reach desired count)."
 	providerDescInstanceGroups = "List of instance groups in the project."
+
+	providerDescRollingUpdate = "Replaces the group's existing instances batch by batch when `instance_template_id` changes. " +
+		"Without this block, existing instances keep running the previous template and only new instances use the new one. " +
+		"Each batch waits for its replacements to be running, and to pass the optional TCP probe on `port`, before the next batch starts. " +
+		"If a batch fails, the update stops and the instances which still run the previous template are recorded in " +
+		"`pending_replacement_instance_ids`; the next apply resumes the update with them."
+	providerDescPendingInstanceIDs = "Instances left on a previous template by a rolling update which stopped early. " +
+		"They are replaced on the next apply while `rolling_update` is set."
+	providerDescRollingUpdateMaxUnavailable = "Maximum number of instances below `desired_count` during the update. Defaults to `1`."
+	providerDescRollingUpdateMaxSurge       = "Maximum number of instances above `desired_count` during the update. " +
+		"Surge instances are started before a batch is deleted. Defaults to `0`. " +
+		"At least one of `max_unavailable` and `max_surge` must be greater than 0."
+	providerDescRollingUpdatePort        = "TCP port which replacement instances must accept connections on, for example `22`, before the next batch starts."
+	providerDescRollingUpdateAddressType = "Which IPv4 address of an instance's first network interface to probe `port` on. " +
+		"Possible values: `public`, `private`. Defaults to `public`."
+	providerDescRollingUpdateBatchTimeout = "How long to wait for each batch's replacements, e.g. `30s` or `15m`. Defaults to `15m`."
+
+	providerDescWaitFor = "Makes create and update wait until the group has `desired_count` active instances, so resources which depend on the group " +
+		"are not applied against a partially built group. If the group doesn't converge within `timeout`, the apply fails and lists the inactive instances and their states. " +
+		"When the group is being created, a timeout is reported as a warning instead, so the new group is kept rather than tainted and replaced."
+	providerDescWaitForTimeout = "How long to wait for the group to converge, e.g. `30s` or `20m`. Defaults to `20m`."
+
+	providerDescDeleteConcurrency = "Maximum number of instances deleted at the same time when the group is destroyed. " +
+		"A failed deletion doesn't stop the others, and destroying again after a failure resumes with the remaining instances. Defaults to `8`."
 )
 
 func instanceGroupToResourceModel(instanceGroup *swagger.InstanceGroup, state *instanceGroupResourceModel, diags *diag.Diagnostics) {
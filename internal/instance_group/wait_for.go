@@ -0,0 +1,137 @@
+package instance_group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+	validators "github.com/crusoecloud/terraform-provider-crusoe/internal/validators"
+)
+
+const waitForDefaultTimeout = "20m"
+
+type waitForResourceModel struct {
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+var waitForSchema = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"timeout": types.StringType,
+	},
+}
+
+func waitForSchemaBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: providerDescWaitFor,
+		Attributes: map[string]schema.Attribute{
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: providerDescWaitForTimeout,
+				Default:             stringdefault.StaticString(waitForDefaultTimeout),
+				Validators:          []validator.String{validators.DurationValidator{}},
+			},
+		},
+	}
+}
+
+// parseWaitForTimeout returns the wait_for timeout, or 0 when the block is absent.
+func parseWaitForTimeout(ctx context.Context, obj types.Object) (time.Duration, error) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return 0, nil
+	}
+
+	var waitFor waitForResourceModel
+	if diags := obj.As(ctx, &waitFor, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return 0, errors.New("failed to read wait_for settings")
+	}
+
+	timeout, err := time.ParseDuration(waitFor.Timeout.ValueString())
+	if err != nil {
+		return 0, fmt.Errorf("invalid wait_for timeout: %w", err)
+	}
+
+	return timeout, nil
+}
+
+// awaitDesiredCount polls the group until it has at least desired_count active instances. Scaling
+// in doesn't delete instances, so more active instances than desired also counts as converged. On
+// timeout the error lists the inactive instances with their VM states.
+func (r *instanceGroupResource) awaitDesiredCount(ctx context.Context, projectID, instanceGroupID string, timeout time.Duration,
+) (*swagger.InstanceGroup, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var group *swagger.InstanceGroup
+	for {
+		current, httpResp, err := r.client.APIClient.InstanceGroupsApi.GetInstanceGroup(waitCtx, instanceGroupID, projectID)
+		if httpResp != nil {
+			httpResp.Body.Close()
+		}
+		if err == nil {
+			group = &current
+			if int64(len(group.ActiveInstances)) >= group.DesiredCount {
+				return group, nil
+			}
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if group == nil {
+				return nil, fmt.Errorf("failed to get the instance group: %w", common.UnpackAPIError(err))
+			}
+
+			// waitCtx has expired, so the instance states are looked up with the caller's context.
+			return group, fmt.Errorf("%d of %d instances became active within %s.%s",
+				len(group.ActiveInstances), group.DesiredCount, timeout,
+				describeInactiveInstances(group.InactiveInstances, r.instanceStates(ctx, projectID, group.InactiveInstances)))
+		case <-time.After(instanceGroupPollInterval):
+		}
+	}
+}
+
+// instanceStates looks up the state of each instance, recording the error instead when the lookup
+// fails.
+func (r *instanceGroupResource) instanceStates(ctx context.Context, projectID string, instanceIDs []string) map[string]string {
+	states := make(map[string]string, len(instanceIDs))
+	for _, instanceID := range instanceIDs {
+		instance, httpResp, err := r.client.APIClient.VMsApi.GetInstance(ctx, projectID, instanceID)
+		if httpResp != nil {
+			httpResp.Body.Close()
+		}
+		if err != nil {
+			states[instanceID] = fmt.Sprintf("state unknown: %s", common.UnpackAPIError(err))
+
+			continue
+		}
+		states[instanceID] = instance.State
+	}
+
+	return states
+}
+
+// describeInactiveInstances lists the inactive instances and why they are inactive, one per line.
+func describeInactiveInstances(instanceIDs []string, states map[string]string) string {
+	if len(instanceIDs) == 0 {
+		return " No instances are reported as inactive; the group has not created the remaining instances yet."
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\nInactive instances:")
+	for _, instanceID := range instanceIDs {
+		fmt.Fprintf(&b, "\n  %s: %s", instanceID, states[instanceID])
+	}
+
+	return b.String()
+}
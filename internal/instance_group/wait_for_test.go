@@ -0,0 +1,55 @@
+package instance_group
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func waitForObject(t *testing.T, timeout string) types.Object {
+	t.Helper()
+
+	obj, diags := types.ObjectValue(waitForSchema.AttrTypes, map[string]attr.Value{
+		"timeout": types.StringValue(timeout),
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to build wait_for object: %v", diags)
+	}
+
+	return obj
+}
+
+func TestParseWaitForTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	timeout, err := parseWaitForTimeout(ctx, types.ObjectNull(waitForSchema.AttrTypes))
+	if err != nil || timeout != 0 {
+		t.Errorf("null block: got (%s, %v), want (0s, nil)", timeout, err)
+	}
+
+	timeout, err = parseWaitForTimeout(ctx, waitForObject(t, "90s"))
+	if err != nil || timeout != 90*time.Second {
+		t.Errorf("got (%s, %v), want (1m30s, nil)", timeout, err)
+	}
+}
+
+func TestDescribeInactiveInstances(t *testing.T) {
+	got := describeInactiveInstances([]string{"vm-1", "vm-2"}, map[string]string{
+		"vm-1": "STATE_PROVISIONING",
+		"vm-2": "STATE_FAILED",
+	})
+
+	for _, want := range []string{"Inactive instances:", "vm-1: STATE_PROVISIONING", "vm-2: STATE_FAILED"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("description %q does not contain %q", got, want)
+		}
+	}
+
+	if got := describeInactiveInstances(nil, nil); !strings.Contains(got, "not created the remaining instances") {
+		t.Errorf("no inactive instances: got %q", got)
+	}
+}
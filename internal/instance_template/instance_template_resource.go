@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -18,6 +19,7 @@ import (
 
 	swagger "github.com/crusoecloud/client-go/swagger/v1"
 	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/ib_network"
 	validators "github.com/crusoecloud/terraform-provider-crusoe/internal/validators"
 )
 
@@ -28,6 +30,11 @@ const (
 	sharedVolume               = "shared-volume"
 )
 
+var (
+	_ resource.Resource               = &instanceTemplateResource{}
+	_ resource.ResourceWithModifyPlan = &instanceTemplateResource{}
+)
+
 type instanceTemplateResource struct {
 	client *common.CrusoeClient
 }
@@ -38,6 +45,7 @@ type instanceTemplateResourceModel struct {
 	Name                types.String `tfsdk:"name"`
 	Type                types.String `tfsdk:"type"`
 	SSHKey              types.String `tfsdk:"ssh_key"`
+	SSHKeys             types.List   `tfsdk:"ssh_keys"`
 	Location            types.String `tfsdk:"location"`
 	Image               types.String `tfsdk:"image"`
 	StartupScript       types.String `tfsdk:"startup_script"`
@@ -118,10 +126,30 @@ func (r *instanceTemplateResource) Schema(ctx context.Context, req resource.Sche
 				},
 			},
 			"ssh_key": schema.StringAttribute{
-				Required:      true,
-				Description:   apiDescSSHKey,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}, // cannot be updated in place
-				Validators:    []validator.String{validators.SSHKeyValidator{}},
+				Optional:    true,
+				Description: apiDescSSHKey,
+				// cannot be updated in place, but moving the same key into ssh_keys is not a change
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIf(
+					common.SSHKeyRequiresReplaceIf,
+					"Recreates the instance template when the set of SSH keys changes.",
+					"Recreates the instance template when the set of SSH keys changes.",
+				)},
+				Validators: []validator.String{
+					validators.SSHKeyValidator{},
+					stringvalidator.AtLeastOneOf(path.MatchRoot("ssh_keys")),
+				},
+			},
+			"ssh_keys": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: providerDescSSHKeys,
+				// cannot be updated in place, but reordering or moving keys from ssh_key is not a change
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplaceIf(
+					common.SSHKeysRequiresReplaceIf,
+					"Recreates the instance template when the set of SSH keys changes.",
+					"Recreates the instance template when the set of SSH keys changes.",
+				)},
+				Validators: []validator.List{validators.SSHKeysValidator{}},
 			},
 			"location": schema.StringAttribute{
 				Optional:      true,
@@ -249,12 +277,20 @@ func (r *instanceTemplateResource) Create(ctx context.Context, req resource.Crea
 		}
 	}
 
+	sshPublicKey, err := common.JoinTFSSHKeys(plan.SSHKey, plan.SSHKeys)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create instance template",
+			fmt.Sprintf("There was an error reading the configured SSH keys: %s", err))
+
+		return
+	}
+
 	dataResp, httpResp, err := r.client.APIClient.InstanceTemplatesApi.CreateInstanceTemplate(ctx, swagger.InstanceTemplatePostRequestV1{
 		TemplateName:        plan.Name.ValueString(),
 		Type_:               plan.Type.ValueString(),
 		Location:            plan.Location.ValueString(),
 		ImageName:           plan.Image.ValueString(),
-		SshPublicKey:        plan.SSHKey.ValueString(),
+		SshPublicKey:        sshPublicKey,
 		StartupScript:       plan.StartupScript.ValueString(),
 		ShutdownScript:      plan.ShutdownScript.ValueString(),
 		SubnetId:            plan.Subnet.ValueString(),
@@ -333,8 +369,69 @@ func (r *instanceTemplateResource) Read(ctx context.Context, req resource.ReadRe
 
 //nolint:gocritic // Implements Terraform defined interface
 func (r *instanceTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Failed to update instance template",
-		"Instance templates are immutable and cannot be updated. Please delete and recreate the resource instead.")
+	// Every other attribute requires replacement, so the only change that can reach Update is moving
+	// the same SSH keys between ssh_key and ssh_keys, which does not touch the template itself.
+	if common.SSHKeysChanged(ctx, req.State, req.Plan) {
+		resp.Diagnostics.AddError("Failed to update instance template",
+			"Instance templates are immutable and cannot be updated. Please delete and recreate the resource instead.")
+
+		return
+	}
+
+	var plan instanceTemplateResourceModel
+	if err := common.GetResourceModel(ctx, req.Plan, &plan, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	var state instanceTemplateResourceModel
+	if err := common.GetResourceModel(ctx, req.State, &state, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	state.SSHKey = plan.SSHKey
+	state.SSHKeys = plan.SSHKeys
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// ModifyPlan warns when the template's IB network has no room for an instance of its type. A
+// template doesn't create instances itself, so a shortfall is never an error here.
+//
+//nolint:gocritic // Implements Terraform defined interface
+func (r *instanceTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan instanceTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.IBPartition.IsNull() || plan.IBPartition.IsUnknown() || plan.Type.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state instanceTemplateResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if state.Type.Equal(plan.Type) && state.IBPartition.Equal(plan.IBPartition) {
+			return
+		}
+	}
+
+	ib_network.CheckCapacity(ctx, r.client.APIClient, &ib_network.CapacityRequest{
+		ProjectID:    common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString()),
+		PartitionID:  plan.IBPartition.ValueString(),
+		InstanceType: plan.Type.ValueString(),
+		Count:        1,
+		Attribute:    path.Root("ib_partition"),
+		WarnOnly:     true,
+	}, &resp.Diagnostics)
 }
 
 //nolint:gocritic // Implements Terraform defined interface
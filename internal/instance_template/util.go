@@ -2,6 +2,7 @@ package instance_template
 
 import (
 	"context"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -35,6 +36,8 @@ const (
 // providerDesc* — provider-specific schema descriptions (Terraform-side; not from the spec).
 const (
 	providerDescProjectID = "ID of the project this instance template belongs to. " + project.ProviderDescProjectIDFallback
+	providerDescSSHKeys   = "SSH public keys to use for all VMs created from this instance template, in addition to `ssh_key`. " +
+		"Keys with the same fingerprint are only added once. At least one of `ssh_key` or `ssh_keys` must be set."
 
 	// providerDescReservationID is provider-side deprecation/behavior text for the
 	// resource-only, plan-owned reservation_id attribute. It is intentionally not
@@ -62,7 +65,13 @@ func instanceTemplateToResourceModel(ctx context.Context, template *swagger.Inst
 	model.Type = types.StringValue(template.Type_)
 	model.Location = types.StringValue(template.Location)
 	model.Image = types.StringValue(template.ImageName)
-	model.SSHKey = types.StringValue(template.SshPublicKey)
+	// With ssh_keys in use the API returns the merged authorized_keys blob, which matches neither
+	// configured attribute, so the configured values are kept instead. The API also trims the key,
+	// so a configured key which only differs by surrounding whitespace (e.g. the trailing newline
+	// from file()) is kept as configured.
+	if model.SSHKeys.IsNull() && strings.TrimSpace(model.SSHKey.ValueString()) != template.SshPublicKey {
+		model.SSHKey = types.StringValue(template.SshPublicKey)
+	}
 	model.Subnet = types.StringValue(template.SubnetId)
 	model.PublicIpAddressType = types.StringValue(template.PublicIpAddressType)
 
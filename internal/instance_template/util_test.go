@@ -101,6 +101,43 @@ func Test_instanceTemplateToResourceModel_createReadIdentical(t *testing.T) {
 	}
 }
 
+// Test_instanceTemplateToResourceModel_sshKeys checks that ssh_key is only taken from the API
+// when ssh_keys is unset, since the API returns the merged keys when ssh_keys is in use.
+func Test_instanceTemplateToResourceModel_sshKeys(t *testing.T) {
+	api := sampleAPITemplate()
+	api.SshPublicKey = "ssh-ed25519 AAAA user@host\nssh-ed25519 BBBB other@host"
+
+	var diags diag.Diagnostics
+	legacy := &instanceTemplateResourceModel{SSHKeys: types.ListNull(types.StringType)}
+	instanceTemplateToResourceModel(context.Background(), api, legacy, &diags)
+	if got := legacy.SSHKey.ValueString(); got != api.SshPublicKey {
+		t.Errorf("ssh_key = %q, want the API value %q when ssh_keys is unset", got, api.SshPublicKey)
+	}
+
+	trailingNewline := &instanceTemplateResourceModel{
+		SSHKey:  types.StringValue(api.SshPublicKey + "\n"),
+		SSHKeys: types.ListNull(types.StringType),
+	}
+	instanceTemplateToResourceModel(context.Background(), api, trailingNewline, &diags)
+	if got := trailingNewline.SSHKey.ValueString(); got != api.SshPublicKey+"\n" {
+		t.Errorf("ssh_key = %q, want the configured value when it only differs from the API by whitespace", got)
+	}
+
+	sshKeys, d := types.ListValueFrom(context.Background(), types.StringType, []string{"ssh-ed25519 BBBB other@host"})
+	diags.Append(d...)
+	withList := &instanceTemplateResourceModel{
+		SSHKey:  types.StringValue("ssh-ed25519 AAAA user@host"),
+		SSHKeys: sshKeys,
+	}
+	instanceTemplateToResourceModel(context.Background(), api, withList, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got := withList.SSHKey.ValueString(); got != "ssh-ed25519 AAAA user@host" {
+		t.Errorf("ssh_key = %q, want the configured value when ssh_keys is set", got)
+	}
+}
+
 func disk(size, diskType string) diskToCreateResourceModel {
 	return diskToCreateResourceModel{Size: types.StringValue(size), Type: types.StringValue(diskType)}
 }
@@ -65,6 +65,7 @@ type kubernetesClusterResourceModel struct {
 	ApiserverExtraArgs         types.Map    `tfsdk:"apiserver_extra_args"`
 	SchedulerExtraArgs         types.Map    `tfsdk:"scheduler_extra_args"`
 	ControllerManagerExtraArgs types.Map    `tfsdk:"controller_manager_extra_args"`
+	DeletionProtection         types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func (r *kubernetesClusterResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
@@ -224,6 +225,7 @@ func (r *kubernetesClusterResource) Schema(ctx context.Context, _ resource.Schem
 				ElementType:         types.StringType,
 				MarkdownDescription: apiDescControllerManagerExtraArgs + " " + providerDescExtraArgsNote,
 			},
+			"deletion_protection": common.DeletionProtectionAttribute("cluster"),
 		},
 	}
 }
@@ -376,6 +378,7 @@ func (r *kubernetesClusterResource) Update(
 	// All three are nil when the user leaves extra args unset (null) in config.
 	// The API rejects an empty PATCH, so skip it and accept the plan's null values.
 	if apiserverArgs == nil && schedulerArgs == nil && controllerManagerArgs == nil {
+		state.DeletionProtection = plan.DeletionProtection
 		state.ApiserverExtraArgs = plan.ApiserverExtraArgs
 		state.SchedulerExtraArgs = plan.SchedulerExtraArgs
 		state.ControllerManagerExtraArgs = plan.ControllerManagerExtraArgs
@@ -431,12 +434,21 @@ func (r *kubernetesClusterResource) Update(
 	response.Diagnostics.Append(diags...)
 }
 
+//nolint:gocritic // Implements Terraform defined interface
+func (r *kubernetesClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	common.ModifyPlanDeletionProtection(ctx, req, resp, "cluster")
+}
+
 //nolint:gocritic // Implements Terraform defined interface
 func (r *kubernetesClusterResource) Delete(
 	ctx context.Context,
 	request resource.DeleteRequest,
 	response *resource.DeleteResponse,
 ) {
+	if common.CheckDeletionProtection(ctx, request.State, "cluster", &response.Diagnostics) {
+		return
+	}
+
 	var stored kubernetesClusterResourceModel
 
 	diags := request.State.Get(ctx, &stored)
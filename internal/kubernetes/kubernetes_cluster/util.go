@@ -57,6 +57,7 @@ const (
 //   - The OIDC* fields are not returned by the API (they are RequiresReplace inputs).
 //   - The *_extra_args maps are resolved against ref so a field the user never set
 //     stays null instead of becoming an empty map when the API echoes {}.
+//   - deletion_protection is enforced by the provider only, defaulting to false on import.
 //
 // nodepool_ids is sorted so its Computed, API-ordered value is stable across reads.
 // ref and model may be the same pointer.
@@ -87,6 +88,7 @@ func clusterToResourceModel(cluster *swagger.KubernetesCluster, ref, model *kube
 	model.OIDCUsernamePrefix = ref.OIDCUsernamePrefix
 	model.OIDCGroupsClaim = ref.OIDCGroupsClaim
 	model.OIDCCACert = ref.OIDCCACert
+	model.DeletionProtection = common.DeletionProtectionOrDefault(ref.DeletionProtection)
 
 	apiserver, d := resolveExtraArg(ref.ApiserverExtraArgs, cluster.ApiserverExtraArgs)
 	diags.Append(d...)
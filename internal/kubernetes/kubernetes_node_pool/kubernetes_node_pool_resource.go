@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -20,6 +21,8 @@ import (
 
 	swagger "github.com/crusoecloud/client-go/swagger/v1"
 	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/ib_network"
+	validators "github.com/crusoecloud/terraform-provider-crusoe/internal/validators"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -50,6 +53,7 @@ type kubernetesNodePoolResourceModel struct {
 	NodeTaints                    types.Set    `tfsdk:"node_taints"`
 	InstanceIDs                   types.List   `tfsdk:"instance_ids"`
 	SSHKey                        types.String `tfsdk:"ssh_key"`
+	SSHKeys                       types.List   `tfsdk:"ssh_keys"`
 	State                         types.String `tfsdk:"state"`
 	Name                          types.String `tfsdk:"name"`
 	EphemeralStorageForContainerd types.Bool   `tfsdk:"ephemeral_storage_for_containerd"`
@@ -167,9 +171,30 @@ func (r *kubernetesNodePoolResource) Schema(_ context.Context, _ resource.Schema
 				MarkdownDescription: apiDescInstanceIDs,
 			},
 			"ssh_key": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				MarkdownDescription: apiDescSSHKey,
-				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()}, // cannot be updated in place
+				// cannot be updated in place, but moving the same key into ssh_keys is not a change
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIf(
+					common.SSHKeyRequiresReplaceIf,
+					"Recreates the node pool when the set of SSH keys changes.",
+					"Recreates the node pool when the set of SSH keys changes.",
+				)},
+				Validators: []validator.String{
+					validators.SSHKeyValidator{},
+					stringvalidator.AtLeastOneOf(path.MatchRoot("ssh_keys")),
+				},
+			},
+			"ssh_keys": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: providerDescSSHKeys,
+				// cannot be updated in place, but reordering or moving keys from ssh_key is not a change
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplaceIf(
+					common.SSHKeysRequiresReplaceIf,
+					"Recreates the node pool when the set of SSH keys changes.",
+					"Recreates the node pool when the set of SSH keys changes.",
+				)},
+				Validators: []validator.List{validators.SSHKeysValidator{}},
 			},
 			"state": schema.StringAttribute{
 				Computed:            true,
@@ -286,6 +311,13 @@ func (r *kubernetesNodePoolResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	sshPublicKey, err := common.JoinTFSSHKeys(plan.SSHKey, plan.SSHKeys)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create node pool", fmt.Sprintf("error when parsing SSH keys: %s", err))
+
+		return
+	}
+
 	asyncOperation, _, err := r.client.APIClient.KubernetesNodePoolsApi.CreateNodePool(ctx, swagger.KubernetesNodePoolPostRequest{
 		ClusterId:                     plan.ClusterID.ValueString(),
 		Count:                         plan.InstanceCount.ValueInt64(),
@@ -295,7 +327,7 @@ func (r *kubernetesNodePoolResource) Create(ctx context.Context, req resource.Cr
 		NodeTaints:                    nodeTaints,
 		NodePoolVersion:               plan.Version.ValueString(),
 		ProductName:                   plan.Type.ValueString(),
-		SshPublicKey:                  plan.SSHKey.ValueString(),
+		SshPublicKey:                  sshPublicKey,
 		SubnetId:                      plan.SubnetID.ValueString(),
 		EphemeralStorageForContainerd: plan.EphemeralStorageForContainerd.ValueBool(),
 		NvlinkDomainId:                plan.NvlinkDomainID.ValueString(),
@@ -367,10 +399,53 @@ func (r *kubernetesNodePoolResource) Read(ctx context.Context, req resource.Read
 	resp.Diagnostics.Append(diags...)
 }
 
+// modifyPlanIBCapacity checks that the node pool's IB network has room for the instances the plan
+// adds: every instance when the pool is created or replaced, and only the increase otherwise.
+//
+//nolint:gocritic // hugeParam: req is passed through from ModifyPlan
+func (r *kubernetesNodePoolResource) modifyPlanIBCapacity(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan kubernetesNodePoolResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.IBPartitionID.IsNull() || plan.IBPartitionID.IsUnknown() || plan.Type.IsUnknown() || plan.InstanceCount.IsUnknown() {
+		return
+	}
+
+	count := plan.InstanceCount.ValueInt64()
+	if !req.State.Raw.IsNull() {
+		var state kubernetesNodePoolResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if state.Type.Equal(plan.Type) && state.IBPartitionID.Equal(plan.IBPartitionID) {
+			count -= state.InstanceCount.ValueInt64()
+		}
+	}
+
+	ib_network.CheckCapacity(ctx, r.client.APIClient, &ib_network.CapacityRequest{
+		ProjectID:    common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString()),
+		PartitionID:  plan.IBPartitionID.ValueString(),
+		InstanceType: plan.Type.ValueString(),
+		Count:        count,
+		Attribute:    path.Root("instance_count"),
+	}, &resp.Diagnostics)
+}
+
 // Handle validation at the resource level to prevent duplicate errors/warnings
 // nolint:gocritic // Implements Terraform defined interface
 func (r *kubernetesNodePoolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	// Only check during updates (skip creates and deletes)
+	r.modifyPlanIBCapacity(ctx, req, resp)
+
+	// Only check the rest during updates (skip creates and deletes)
 	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
 		return
 	}
@@ -54,6 +54,8 @@ const (
 // providerDesc* — provider-specific schema descriptions (Terraform-side; not from the spec).
 const (
 	providerDescProjectID = "ID of the project that owns the node pool. " + project.ProviderDescProjectIDFallback
+	providerDescSSHKeys   = "SSH public keys to use for all VMs created from the new node pool, in addition to `ssh_key`. " +
+		"Keys with the same fingerprint are only added once. At least one of `ssh_key` or `ssh_keys` must be set."
 
 	providerDescBatchSize = common.DevelopmentMessage + " " +
 		"Number of nodes to update at a time during rollout (minimum 1, maximum 10). " +
@@ -258,7 +260,7 @@ func validateNodeTaintDuplicates(taints []swagger.KubernetesNodeTaint) error {
 // particular the instance_ids sort (CCX-4394) and the nvlink_domain_id
 // empty-to-null normalization now live in exactly one place.
 //
-// The Terraform-only fields the API does not return (ib_partition_id, ssh_key,
+// The Terraform-only fields the API does not return (ib_partition_id, ssh_key, ssh_keys,
 // requested_node_labels, batch_size, batch_percentage) are taken from ref: the
 // plan in Create/Update, the prior state in Read.
 func nodePoolToResourceModel(ctx context.Context, nodePool *swagger.KubernetesNodePool,
@@ -292,6 +294,7 @@ func nodePoolToResourceModel(ctx context.Context, nodePool *swagger.KubernetesNo
 	// Terraform-only fields (not returned by the API) come from the reference model.
 	model.IBPartitionID = ref.IBPartitionID
 	model.SSHKey = ref.SSHKey
+	model.SSHKeys = ref.SSHKeys
 	model.BatchSize = ref.BatchSize
 	model.BatchPercentage = ref.BatchPercentage
 	if ref.RequestedNodeLabels.IsUnknown() {
@@ -2,11 +2,17 @@ package load_balancer
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
 	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
 )
 
@@ -15,8 +21,14 @@ type loadBalancerDataSource struct {
 }
 
 type loadBalancerDataSourceModel struct {
-	ProjectID     types.String        `tfsdk:"project_id"`
-	LoadBalancers []loadBalancerModel `tfsdk:"load_balancers"`
+	ProjectID             types.String        `tfsdk:"project_id"`
+	ID                    *string             `tfsdk:"id"`
+	Name                  *string             `tfsdk:"name"`
+	Network               *string             `tfsdk:"network"`
+	Location              *string             `tfsdk:"location"`
+	Type                  *string             `tfsdk:"type"`
+	DestinationResourceID *string             `tfsdk:"destination_resource_id"`
+	LoadBalancers         []loadBalancerModel `tfsdk:"load_balancers"`
 }
 
 type networkInterfaceModel struct {
@@ -38,25 +50,18 @@ type lbIPv4 struct {
 	Address string `tfsdk:"address"`
 }
 
-type healthCheckOptionsModel struct {
-	Timeout      string `tfsdk:"timeout"`
-	Port         string `tfsdk:"port"`
-	Interval     string `tfsdk:"interval"`
-	SuccessCount string `tfsdk:"success_count"`
-	FailureCount string `tfsdk:"failure_count"`
-}
-
 type loadBalancerModel struct {
-	ID                string                   `tfsdk:"id"`
-	Name              string                   `tfsdk:"name"`
-	NetworkInterfaces []networkInterfaceModel  `tfsdk:"network_interfaces"`
-	Destinations      []destinationModel       `tfsdk:"destinations"`
-	Location          string                   `tfsdk:"location"`
-	Protocols         []string                 `tfsdk:"protocols"`
-	Algorithm         string                   `tfsdk:"algorithm"`
-	Type              string                   `tfsdk:"type"`
-	IPs               []ipAddressesModel       `tfsdk:"ips"`
-	HealthCheck       *healthCheckOptionsModel `tfsdk:"health_check"`
+	ID                string                           `tfsdk:"id"`
+	Name              string                           `tfsdk:"name"`
+	NetworkInterfaces []networkInterfaceModel          `tfsdk:"network_interfaces"`
+	Destinations      []destinationModel               `tfsdk:"destinations"`
+	Location          string                           `tfsdk:"location"`
+	Protocols         []string                         `tfsdk:"protocols"`
+	Algorithm         string                           `tfsdk:"algorithm"`
+	Type              string                           `tfsdk:"type"`
+	IPs               []ipAddressesModel               `tfsdk:"ips"`
+	PublicIPv4        []string                         `tfsdk:"public_ipv4_addresses"`
+	HealthCheck       *healthCheckOptionsResourceModel `tfsdk:"health_check"`
 }
 
 func NewLoadBalancerDataSource() datasource.DataSource {
@@ -89,6 +94,31 @@ func (ds *loadBalancerDataSource) Schema(ctx context.Context, request datasource
 	response.Schema = schema.Schema{
 		MarkdownDescription: common.DevelopmentMessage,
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescIDLookup,
+				Validators:          []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("name"))},
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescNameLookup,
+			},
+			"network": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescNetworkFilter,
+			},
+			"location": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescLocationFilter,
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescTypeFilter,
+			},
+			"destination_resource_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescDestinationResourceIDFilter,
+			},
 			"load_balancers": schema.ListNestedAttribute{
 				Computed:            true,
 				MarkdownDescription: providerDescLoadBalancers,
@@ -178,26 +208,31 @@ func (ds *loadBalancerDataSource) Schema(ctx context.Context, request datasource
 								},
 							},
 						},
+						"public_ipv4_addresses": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: providerDescPublicIPv4Addresses,
+						},
 						"health_check": schema.SingleNestedAttribute{
 							Computed: true,
 							Attributes: map[string]schema.Attribute{
-								"timeout": schema.StringAttribute{
+								"timeout": schema.Int64Attribute{
 									Computed:            true,
 									MarkdownDescription: apiDescHealthCheckTimeout,
 								},
-								"port": schema.StringAttribute{
+								"port": schema.Int64Attribute{
 									Computed:            true,
 									MarkdownDescription: apiDescHealthCheckPort,
 								},
-								"interval": schema.StringAttribute{
+								"interval": schema.Int64Attribute{
 									Computed:            true,
 									MarkdownDescription: apiDescHealthCheckInterval,
 								},
-								"success_count": schema.StringAttribute{
+								"success_count": schema.Int64Attribute{
 									Computed:            true,
 									MarkdownDescription: apiDescHealthCheckSuccessCount,
 								},
-								"failure_count": schema.StringAttribute{
+								"failure_count": schema.Int64Attribute{
 									Computed:            true,
 									MarkdownDescription: apiDescHealthCheckFailureCount,
 								},
@@ -225,36 +260,53 @@ func (ds *loadBalancerDataSource) Read(ctx context.Context, req datasource.ReadR
 
 	projectID := common.GetProjectIDOrFallback(ds.client, config.ProjectID.ValueString())
 
-	dataResp, httpResp, err := ds.client.APIClient.InternalLoadBalancersApi.ListLoadBalancers(ctx, projectID)
-	if httpResp != nil {
-		defer httpResp.Body.Close()
+	var loadBalancers []swagger.LoadBalancer
+	if config.ID != nil {
+		loadBalancer, httpResp, err := ds.client.APIClient.InternalLoadBalancersApi.GetLoadBalancer(ctx, projectID, *config.ID)
+		if httpResp != nil {
+			defer httpResp.Body.Close()
+		}
+		if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+			resp.Diagnostics.AddError("Failed to fetch load balancer",
+				fmt.Sprintf("Could not fetch load balancer %s at this time: %s", *config.ID, common.UnpackAPIError(err)))
+
+			return
+		}
+		if err == nil {
+			loadBalancers = append(loadBalancers, loadBalancer)
+		}
+	} else {
+		dataResp, httpResp, err := ds.client.APIClient.InternalLoadBalancersApi.ListLoadBalancers(ctx, projectID)
+		if httpResp != nil {
+			defer httpResp.Body.Close()
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to fetch load balancers", "Could not fetch load balancers data at this time.")
+
+			return
+		}
+		loadBalancers = dataResp.Items
 	}
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to fetch load balancers", "Could not fetch load balancers data at this time.")
 
-		return
+	if config.ID != nil || config.Name != nil {
+		loadBalancer, err := common.SelectSingle(loadBalancers, func(lb *swagger.LoadBalancer) bool {
+			return loadBalancerMatches(lb, &config)
+		}, "load balancer", loadBalancerLookupCriteria(&config))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to find load balancer",
+				fmt.Sprintf("Could not find a load balancer in project %s: %s", projectID, err))
+
+			return
+		}
+		loadBalancers = []swagger.LoadBalancer{*loadBalancer}
+	} else {
+		loadBalancers = filterLoadBalancers(loadBalancers, &config)
 	}
 
-	var state loadBalancerDataSourceModel
-	for i := range dataResp.Items {
-		state.LoadBalancers = append(state.LoadBalancers, loadBalancerModel{
-			ID:                dataResp.Items[i].Id,
-			Name:              dataResp.Items[i].Name,
-			NetworkInterfaces: loadBalancerNetworkInterfacesToTerraformDataModel(dataResp.Items[i].NetworkInterfaces),
-			Destinations:      loadBalancerDestinationsToTerraformDataModel(dataResp.Items[i].Destinations),
-			Location:          dataResp.Items[i].Location,
-			Protocols:         dataResp.Items[i].Protocols,
-			Algorithm:         dataResp.Items[i].Algorithm,
-			Type:              dataResp.Items[i].Type_,
-			IPs:               loadBalancerIPsToTerraformDataModel(dataResp.Items[i].Ips),
-			HealthCheck: &healthCheckOptionsModel{
-				Timeout:      dataResp.Items[i].HealthCheck.Timeout,
-				Port:         dataResp.Items[i].HealthCheck.Port,
-				Interval:     dataResp.Items[i].HealthCheck.Interval,
-				SuccessCount: dataResp.Items[i].HealthCheck.SuccessCount,
-				FailureCount: dataResp.Items[i].HealthCheck.FailureCount,
-			},
-		})
+	state := config
+	state.LoadBalancers = make([]loadBalancerModel, 0, len(loadBalancers))
+	for i := range loadBalancers {
+		state.LoadBalancers = append(state.LoadBalancers, loadBalancerToDataSourceModel(&loadBalancers[i]))
 	}
 
 	// Sort load balancers deterministically so repeated reads produce a stable ordering.
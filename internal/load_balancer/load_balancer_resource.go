@@ -5,38 +5,53 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
 	swagger "github.com/crusoecloud/client-go/swagger/v1"
 	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
 	validators "github.com/crusoecloud/terraform-provider-crusoe/internal/validators"
 )
 
+var (
+	_ resource.Resource                   = &loadBalancerResource{}
+	_ resource.ResourceWithImportState    = &loadBalancerResource{}
+	_ resource.ResourceWithValidateConfig = &loadBalancerResource{}
+	_ resource.ResourceWithModifyPlan     = &loadBalancerResource{}
+	_ resource.ResourceWithUpgradeState   = &loadBalancerResource{}
+)
+
 type loadBalancerResource struct {
 	client *common.CrusoeClient
 }
 
 type loadBalancerResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	ProjectID         types.String `tfsdk:"project_id"`
-	Name              types.String `tfsdk:"name"`
-	NetworkInterfaces types.List   `tfsdk:"network_interfaces"`
-	Destinations      types.List   `tfsdk:"destinations"`
-	Location          types.String `tfsdk:"location"`
-	Protocols         types.List   `tfsdk:"protocols"`
-	Algorithm         types.String `tfsdk:"algorithm"`
-	Type              types.String `tfsdk:"type"`
-	IPs               types.List   `tfsdk:"ips"`
-	HealthCheck       types.Object `tfsdk:"health_check"`
+	ID                         types.String `tfsdk:"id"`
+	ProjectID                  types.String `tfsdk:"project_id"`
+	Name                       types.String `tfsdk:"name"`
+	NetworkInterfaces          types.List   `tfsdk:"network_interfaces"`
+	Destinations               types.List   `tfsdk:"destinations"`
+	DestinationInstanceGroupID types.String `tfsdk:"destination_instance_group_id"`
+	DestinationInstanceIDs     types.List   `tfsdk:"destination_instance_ids"`
+	Location                   types.String `tfsdk:"location"`
+	Protocols                  types.List   `tfsdk:"protocols"`
+	Algorithm                  types.String `tfsdk:"algorithm"`
+	Type                       types.String `tfsdk:"type"`
+	IPs                        types.List   `tfsdk:"ips"`
+	HealthCheck                types.Object `tfsdk:"health_check"`
 }
 
 type loadBalancerNetworkTargetModel struct {
@@ -61,11 +76,11 @@ type loadBalancerPublicIPv4ResourceModel struct {
 }
 
 type healthCheckOptionsResourceModel struct {
-	Timeout      types.String `tfsdk:"timeout"`
-	Port         types.String `tfsdk:"port"`
-	Interval     types.String `tfsdk:"interval"`
-	SuccessCount types.String `tfsdk:"success_count"`
-	FailureCount types.String `tfsdk:"failure_count"`
+	Timeout      types.Int64 `tfsdk:"timeout"`
+	Port         types.Int64 `tfsdk:"port"`
+	Interval     types.Int64 `tfsdk:"interval"`
+	SuccessCount types.Int64 `tfsdk:"success_count"`
+	FailureCount types.Int64 `tfsdk:"failure_count"`
 }
 
 func NewLoadBalancerResource() resource.Resource {
@@ -97,7 +112,7 @@ func (r *loadBalancerResource) Metadata(ctx context.Context, req resource.Metada
 func (r *loadBalancerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: common.DevelopmentMessage,
-		Version:             1,
+		Version:             2,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -138,8 +153,8 @@ func (r *loadBalancerResource) Schema(ctx context.Context, req resource.SchemaRe
 				},
 			},
 			"destinations": schema.ListNestedAttribute{
-				Required:            true,
-				MarkdownDescription: apiDescDestinations,
+				Optional:            true,
+				MarkdownDescription: providerDescDestinationsWithGroup,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"cidr": schema.StringAttribute{
@@ -158,6 +173,15 @@ func (r *loadBalancerResource) Schema(ctx context.Context, req resource.SchemaRe
 					},
 				},
 			},
+			"destination_instance_group_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescDestinationInstanceGroupID,
+			},
+			"destination_instance_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: providerDescDestinationInstanceIDs,
+			},
 			"location": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: apiDescLocation,
@@ -225,30 +249,40 @@ func (r *loadBalancerResource) Schema(ctx context.Context, req resource.SchemaRe
 				Computed:      true,
 				PlanModifiers: []planmodifier.Object{objectplanmodifier.UseStateForUnknown()}, // maintain across updates
 				Attributes: map[string]schema.Attribute{
-					"timeout": schema.StringAttribute{
+					"timeout": schema.Int64Attribute{
+						Optional:            true,
 						Computed:            true,
 						MarkdownDescription: apiDescHealthCheckTimeout,
-						PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, // maintain across updates
+						Default:             int64default.StaticInt64(defaultHealthCheckTimeout),
+						Validators:          []validator.Int64{int64validator.AtLeast(1)},
 					},
-					"port": schema.StringAttribute{
+					"port": schema.Int64Attribute{
+						Optional:            true,
 						Computed:            true,
 						MarkdownDescription: apiDescHealthCheckPort,
-						PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, // maintain across updates
+						PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}, // maintain across updates
+						Validators:          []validator.Int64{int64validator.Between(1, 65535)},
 					},
-					"interval": schema.StringAttribute{
+					"interval": schema.Int64Attribute{
+						Optional:            true,
 						Computed:            true,
 						MarkdownDescription: apiDescHealthCheckInterval,
-						PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, // maintain across updates
+						Default:             int64default.StaticInt64(defaultHealthCheckInterval),
+						Validators:          []validator.Int64{int64validator.AtLeast(1)},
 					},
-					"success_count": schema.StringAttribute{
+					"success_count": schema.Int64Attribute{
+						Optional:            true,
 						Computed:            true,
 						MarkdownDescription: apiDescHealthCheckSuccessCount,
-						PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, // maintain across updates
+						Default:             int64default.StaticInt64(defaultHealthCheckSuccessCount),
+						Validators:          []validator.Int64{int64validator.AtLeast(1)},
 					},
-					"failure_count": schema.StringAttribute{
+					"failure_count": schema.Int64Attribute{
+						Optional:            true,
 						Computed:            true,
 						MarkdownDescription: apiDescHealthCheckFailureCount,
-						PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, // maintain across updates
+						Default:             int64default.StaticInt64(defaultHealthCheckFailureCount),
+						Validators:          []validator.Int64{int64validator.AtLeast(1)},
 					},
 				},
 			},
@@ -256,6 +290,73 @@ func (r *loadBalancerResource) Schema(ctx context.Context, req resource.SchemaRe
 	}
 }
 
+// ValidateConfig checks that the load balancer has somewhere to send traffic and the health
+// check options against each other. Schema validators cover each option on its own.
+//
+//nolint:gocritic // Implements Terraform defined interface
+func (r *loadBalancerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config loadBalancerResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Destinations.IsNull() && config.DestinationInstanceGroupID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("destinations"), "Missing load balancer destinations",
+			"At least one of destinations or destination_instance_group_id must be set.")
+	}
+
+	if config.HealthCheck.IsNull() || config.HealthCheck.IsUnknown() {
+		return
+	}
+
+	var healthCheck healthCheckOptionsResourceModel
+	resp.Diagnostics.Append(config.HealthCheck.As(ctx, &healthCheck, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := validateHealthCheckTiming(&healthCheck); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("health_check").AtName("timeout"), "Invalid health check", err.Error())
+	}
+}
+
+// ModifyPlan plans destination_instance_ids from the instance group's current active instances,
+// so instances joining or leaving the group show up as an update.
+//
+//nolint:gocritic // Implements Terraform defined interface
+func (r *loadBalancerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan loadBalancerResourceModel
+	if err := common.GetResourceModel(ctx, req.Plan, &plan, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	planned := types.ListNull(types.StringType)
+	switch {
+	case plan.DestinationInstanceGroupID.IsUnknown() || r.client == nil:
+		planned = types.ListUnknown(types.StringType)
+	case !plan.DestinationInstanceGroupID.IsNull():
+		projectID := common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString())
+		instanceIDs, err := instanceGroupActiveInstanceIDs(ctx, r.client.APIClient, projectID, plan.DestinationInstanceGroupID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("destination_instance_group_id"), "Failed to get instance group",
+				fmt.Sprintf("There was an error fetching the destination instance group: %s", err))
+
+			return
+		}
+
+		var diags diag.Diagnostics
+		planned, diags = types.ListValueFrom(ctx, types.StringType, instanceIDs)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("destination_instance_ids"), planned)...)
+}
+
 func (r *loadBalancerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resourceID, projectID, errMsg := common.ParseResourceIdentifiers(req, r.client, "load_balancer_id")
 	if errMsg != "" {
@@ -313,7 +414,16 @@ func (r *loadBalancerResource) Create(ctx context.Context, req resource.CreateRe
 			ResourceId: d.ResourceID.ValueString(),
 		})
 	}
-	postReq.Destinations = destinations
+
+	// project id
+	projectID := common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString())
+
+	// instance group destinations
+	groupInstanceIDs, ok := r.destinationGroupInstanceIDs(ctx, &plan, projectID, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	postReq.Destinations = appendGroupDestinations(destinations, groupInstanceIDs)
 
 	// health check
 	postReq.HealthCheck = healthCheckToSwagger(ctx, plan.HealthCheck, &resp.Diagnostics)
@@ -330,9 +440,6 @@ func (r *loadBalancerResource) Create(ctx context.Context, req resource.CreateRe
 	}
 	postReq.Protocols = protocols
 
-	// project id
-	projectID := common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString())
-
 	dataResp, httpResp, err := r.client.APIClient.InternalLoadBalancersApi.CreateLoadBalancer(ctx, postReq, projectID)
 	if httpResp != nil {
 		defer httpResp.Body.Close()
@@ -353,7 +460,7 @@ func (r *loadBalancerResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	loadBalancerUpdateTerraformState(ctx, loadBalancer, &plan)
+	loadBalancerUpdateTerraformState(ctx, loadBalancer, groupInstanceIDs, &plan)
 	plan.ProjectID = types.StringValue(projectID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
@@ -386,8 +493,21 @@ func (r *loadBalancerResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	// Destinations added for the instance group are the ones recorded in state plus any instance
+	// currently active in the group, so membership changes on either side show up as drift.
+	var groupInstanceIDs []string
+	if !state.DestinationInstanceGroupID.IsNull() {
+		resp.Diagnostics.Append(state.DestinationInstanceIDs.ElementsAs(ctx, &groupInstanceIDs, true)...)
+		activeInstanceIDs, err := instanceGroupActiveInstanceIDs(ctx, r.client.APIClient, projectID, state.DestinationInstanceGroupID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddWarning("Failed to get instance group",
+				fmt.Sprintf("There was an error fetching the destination instance group, so its membership could not be checked: %s", err))
+		}
+		groupInstanceIDs = append(groupInstanceIDs, activeInstanceIDs...)
+	}
+
 	state.ProjectID = types.StringValue(projectID)
-	loadBalancerUpdateTerraformState(ctx, &loadBalancer, &state)
+	loadBalancerUpdateTerraformState(ctx, &loadBalancer, groupInstanceIDs, &state)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -409,7 +529,14 @@ func (r *loadBalancerResource) Update(ctx context.Context, req resource.UpdateRe
 		patchReq.Name = plan.Name.ValueString()
 	}
 
-	if !plan.Destinations.IsNull() && !plan.Destinations.IsUnknown() {
+	groupInstanceIDs, ok := r.destinationGroupInstanceIDs(ctx, &plan, plan.ProjectID.ValueString(), &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	// Destinations are sent whenever they are configured, or when instance group destinations may
+	// have to be added or removed.
+	if !plan.Destinations.IsUnknown() && (!plan.Destinations.IsNull() || !plan.DestinationInstanceGroupID.IsNull() || !state.DestinationInstanceGroupID.IsNull()) {
 		tDestinations := make([]loadBalancerNetworkTargetModel, 0, len(plan.Destinations.Elements()))
 		diags := plan.Destinations.ElementsAs(ctx, &tDestinations, true)
 		resp.Diagnostics.Append(diags...)
@@ -423,7 +550,7 @@ func (r *loadBalancerResource) Update(ctx context.Context, req resource.UpdateRe
 				ResourceId: d.ResourceID.ValueString(),
 			})
 		}
-		patchReq.Destinations = destinations
+		patchReq.Destinations = appendGroupDestinations(destinations, groupInstanceIDs)
 	}
 
 	patchReq.HealthCheck = healthCheckToSwagger(ctx, plan.HealthCheck, &resp.Diagnostics)
@@ -456,7 +583,7 @@ func (r *loadBalancerResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	loadBalancerUpdateTerraformState(ctx, loadBalancer, &plan)
+	loadBalancerUpdateTerraformState(ctx, loadBalancer, groupInstanceIDs, &plan)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
@@ -489,3 +616,30 @@ func (r *loadBalancerResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 }
+
+// destinationGroupInstanceIDs returns the instances of the plan's destination instance group, or
+// nil when no group is set. The planned instances are used when known, so apply targets what the
+// plan showed; otherwise the group's active instances are fetched. ok is false on failure.
+func (r *loadBalancerResource) destinationGroupInstanceIDs(ctx context.Context, plan *loadBalancerResourceModel, projectID string,
+	diags *diag.Diagnostics,
+) (instanceIDs []string, ok bool) {
+	if plan.DestinationInstanceGroupID.IsNull() {
+		return nil, true
+	}
+
+	if !plan.DestinationInstanceIDs.IsNull() && !plan.DestinationInstanceIDs.IsUnknown() {
+		diags.Append(plan.DestinationInstanceIDs.ElementsAs(ctx, &instanceIDs, true)...)
+
+		return instanceIDs, !diags.HasError()
+	}
+
+	instanceIDs, err := instanceGroupActiveInstanceIDs(ctx, r.client.APIClient, projectID, plan.DestinationInstanceGroupID.ValueString())
+	if err != nil {
+		diags.AddError("Failed to get instance group",
+			fmt.Sprintf("There was an error fetching the destination instance group: %s", err))
+
+		return nil, false
+	}
+
+	return instanceIDs, true
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 
 	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
@@ -103,3 +106,51 @@ func TestLoadBalancerImportState(t *testing.T) {
 		}
 	})
 }
+
+// TestUpgradeHealthCheckV1ToV2 checks that string health check options from v1 state are
+// converted to integers, with unparseable values dropped to null.
+func TestUpgradeHealthCheckV1ToV2(t *testing.T) {
+	ctx := context.Background()
+
+	prior, d := types.ObjectValueFrom(ctx, map[string]attr.Type{
+		"timeout":       types.StringType,
+		"port":          types.StringType,
+		"interval":      types.StringType,
+		"success_count": types.StringType,
+		"failure_count": types.StringType,
+	}, healthCheckOptionsModelV1{
+		Timeout:      types.StringValue("5s"),
+		Port:         types.StringValue("abc"),
+		Interval:     types.StringValue("10"),
+		SuccessCount: types.StringValue("3"),
+		FailureCount: types.StringValue("2"),
+	})
+	if d.HasError() {
+		t.Fatalf("building object: %v", d)
+	}
+
+	var diags diag.Diagnostics
+	upgraded := upgradeHealthCheckV1ToV2(ctx, prior, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var got healthCheckOptionsResourceModel
+	if d := upgraded.As(ctx, &got, basetypes.ObjectAsOptions{}); d.HasError() {
+		t.Fatalf("decoding upgraded object: %v", d)
+	}
+	want := healthCheckOptionsResourceModel{
+		Timeout:      types.Int64Value(5),
+		Port:         types.Int64Null(),
+		Interval:     types.Int64Value(10),
+		SuccessCount: types.Int64Value(3),
+		FailureCount: types.Int64Value(2),
+	}
+	if got != want {
+		t.Errorf("upgraded health_check = %+v, want %+v", got, want)
+	}
+
+	if null := upgradeHealthCheckV1ToV2(ctx, types.ObjectNull(prior.AttributeTypes(ctx)), &diags); !null.IsNull() {
+		t.Errorf("upgrading a null health_check = %s, want null", null)
+	}
+}
@@ -0,0 +1,121 @@
+package load_balancer
+
+import (
+	"context"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// loadBalancerResourceModelV1 is the v1 state, before the health check options became integers
+// and destination_instance_group_id was added.
+type loadBalancerResourceModelV1 struct {
+	ID                types.String `tfsdk:"id"`
+	ProjectID         types.String `tfsdk:"project_id"`
+	Name              types.String `tfsdk:"name"`
+	NetworkInterfaces types.List   `tfsdk:"network_interfaces"`
+	Destinations      types.List   `tfsdk:"destinations"`
+	Location          types.String `tfsdk:"location"`
+	Protocols         types.List   `tfsdk:"protocols"`
+	Algorithm         types.String `tfsdk:"algorithm"`
+	Type              types.String `tfsdk:"type"`
+	IPs               types.List   `tfsdk:"ips"`
+	HealthCheck       types.Object `tfsdk:"health_check"`
+}
+
+// healthCheckOptionsModelV1 is the health_check object before its options became integers.
+type healthCheckOptionsModelV1 struct {
+	Timeout      types.String `tfsdk:"timeout"`
+	Port         types.String `tfsdk:"port"`
+	Interval     types.String `tfsdk:"interval"`
+	SuccessCount types.String `tfsdk:"success_count"`
+	FailureCount types.String `tfsdk:"failure_count"`
+}
+
+func (r *loadBalancerResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	// The prior schema is the current one without the attributes added in v2 and with the
+	// string health check options swapped back in.
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	priorSchema := schemaResp.Schema
+	priorSchema.Version = 1
+	priorSchema.Attributes = maps.Clone(priorSchema.Attributes)
+	delete(priorSchema.Attributes, "destination_instance_group_id")
+	delete(priorSchema.Attributes, "destination_instance_ids")
+	priorSchema.Attributes["health_check"] = schema.SingleNestedAttribute{
+		Optional: true,
+		Computed: true,
+		Attributes: map[string]schema.Attribute{
+			"timeout":       schema.StringAttribute{Computed: true},
+			"port":          schema.StringAttribute{Computed: true},
+			"interval":      schema.StringAttribute{Computed: true},
+			"success_count": schema.StringAttribute{Computed: true},
+			"failure_count": schema.StringAttribute{Computed: true},
+		},
+	}
+
+	return map[int64]resource.StateUpgrader{
+		1: {
+			PriorSchema:   &priorSchema,
+			StateUpgrader: upgradeStateV1ToV2,
+		},
+	}
+}
+
+func upgradeStateV1ToV2(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior loadBalancerResourceModelV1
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := loadBalancerResourceModel{
+		ID:                         prior.ID,
+		ProjectID:                  prior.ProjectID,
+		Name:                       prior.Name,
+		NetworkInterfaces:          prior.NetworkInterfaces,
+		Destinations:               prior.Destinations,
+		DestinationInstanceGroupID: types.StringNull(),
+		DestinationInstanceIDs:     types.ListNull(types.StringType),
+		Location:                   prior.Location,
+		Protocols:                  prior.Protocols,
+		Algorithm:                  prior.Algorithm,
+		Type:                       prior.Type,
+		IPs:                        prior.IPs,
+		HealthCheck:                upgradeHealthCheckV1ToV2(ctx, prior.HealthCheck, &resp.Diagnostics),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// upgradeHealthCheckV1ToV2 converts a string-valued health_check object to the integer-valued
+// one. Options which don't parse become null and are refreshed by the next Read.
+func upgradeHealthCheckV1ToV2(ctx context.Context, obj types.Object, diags *diag.Diagnostics) types.Object {
+	if obj.IsNull() || obj.IsUnknown() {
+		return types.ObjectNull(loadBalancerHealthCheckSchema.AttrTypes)
+	}
+
+	var prior healthCheckOptionsModelV1
+	diags.Append(obj.As(ctx, &prior, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return types.ObjectNull(loadBalancerHealthCheckSchema.AttrTypes)
+	}
+
+	upgraded, d := types.ObjectValueFrom(ctx, loadBalancerHealthCheckSchema.AttrTypes, healthCheckOptionsResourceModel{
+		Timeout:      parseHealthCheckValue(prior.Timeout.ValueString()),
+		Port:         parseHealthCheckValue(prior.Port.ValueString()),
+		Interval:     parseHealthCheckValue(prior.Interval.ValueString()),
+		SuccessCount: parseHealthCheckValue(prior.SuccessCount.ValueString()),
+		FailureCount: parseHealthCheckValue(prior.FailureCount.ValueString()),
+	})
+	diags.Append(d...)
+
+	return upgraded
+}
@@ -2,6 +2,10 @@ package load_balancer
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -9,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
 	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
 	"github.com/crusoecloud/terraform-provider-crusoe/internal/project"
 )
 
@@ -50,12 +55,34 @@ const (
 	apiDescHealthCheckFailureCount = "Number of allowed failures before considering a backend unhealthy."
 )
 
+// Health check defaults, applied when a health_check is configured without them.
+const (
+	defaultHealthCheckTimeout      = 5
+	defaultHealthCheckInterval     = 10
+	defaultHealthCheckSuccessCount = 2
+	defaultHealthCheckFailureCount = 3
+)
+
 // providerDesc* — provider-specific schema descriptions (Terraform-side; not from the spec).
 const (
 	// The LoadBalancer read model has no project_id property, so the base text is
 	// provider-authored here rather than sourced from the spec.
 	providerDescProjectID     = "ID of the project the load balancer belongs to. " + project.ProviderDescProjectIDFallback
-	providerDescLoadBalancers = "List of load balancers in the project."
+	providerDescLoadBalancers = "Load balancers in the project matching all of the given filters, sorted by name."
+
+	providerDescIDLookup                    = "Only return the load balancer with this ID. Conflicts with `name`."
+	providerDescNameLookup                  = "Only return load balancers with this name."
+	providerDescNetworkFilter               = "Only return load balancers with a network interface in this VPC network ID."
+	providerDescLocationFilter              = "Only return load balancers in this location."
+	providerDescTypeFilter                  = "Only return load balancers of this type."
+	providerDescDestinationResourceIDFilter = "Only return load balancers with a destination targeting this resource ID."
+	providerDescPublicIPv4Addresses         = "Public IPv4 addresses of the load balancer, flattened from `ips`."
+
+	providerDescDestinationInstanceGroupID = "ID of an instance group whose active instances are added to the load balancer's destinations." +
+		" The destinations follow the group as it scales; membership changes show up in the next plan."
+	providerDescDestinationInstanceIDs = "IDs of the instances the load balancer forwards to because they are active in" +
+		" `destination_instance_group_id`."
+	providerDescDestinationsWithGroup = apiDescDestinations + " Instances added through `destination_instance_group_id` are not listed here."
 )
 
 var loadBalancerNetworkInterfaceSchema = types.ObjectType{
@@ -91,11 +118,11 @@ var loadBalancerIPAddressSchema = types.ObjectType{
 
 var loadBalancerHealthCheckSchema = types.ObjectType{
 	AttrTypes: map[string]attr.Type{
-		"timeout":       types.StringType,
-		"port":          types.StringType,
-		"interval":      types.StringType,
-		"success_count": types.StringType,
-		"failure_count": types.StringType,
+		"timeout":       types.Int64Type,
+		"port":          types.Int64Type,
+		"interval":      types.Int64Type,
+		"success_count": types.Int64Type,
+		"failure_count": types.Int64Type,
 	},
 }
 
@@ -138,6 +165,87 @@ func loadBalancerIPsToTerraformDataModel(ips []swagger.IpAddresses,
 	return lbIPs
 }
 
+// publicIPv4Addresses flattens the public IPv4 addresses out of a load balancer's IPs.
+func publicIPv4Addresses(ips []swagger.IpAddresses) []string {
+	addresses := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		if ip.PublicIpv4 != nil && ip.PublicIpv4.Address != "" {
+			addresses = append(addresses, ip.PublicIpv4.Address)
+		}
+	}
+
+	return addresses
+}
+
+func loadBalancerToDataSourceModel(lb *swagger.LoadBalancer) loadBalancerModel {
+	model := loadBalancerModel{
+		ID:                lb.Id,
+		Name:              lb.Name,
+		NetworkInterfaces: loadBalancerNetworkInterfacesToTerraformDataModel(lb.NetworkInterfaces),
+		Destinations:      loadBalancerDestinationsToTerraformDataModel(lb.Destinations),
+		Location:          lb.Location,
+		Protocols:         lb.Protocols,
+		Algorithm:         lb.Algorithm,
+		Type:              lb.Type_,
+		IPs:               loadBalancerIPsToTerraformDataModel(lb.Ips),
+		PublicIPv4:        publicIPv4Addresses(lb.Ips),
+	}
+	if lb.HealthCheck != nil {
+		model.HealthCheck = loadBalancerHealthCheckToTerraformResourceModel(lb.HealthCheck)
+	}
+
+	return model
+}
+
+// filterLoadBalancers returns the load balancers matching every filter set in config.
+func filterLoadBalancers(loadBalancers []swagger.LoadBalancer, config *loadBalancerDataSourceModel) []swagger.LoadBalancer {
+	filtered := make([]swagger.LoadBalancer, 0, len(loadBalancers))
+	for i := range loadBalancers {
+		if loadBalancerMatches(&loadBalancers[i], config) {
+			filtered = append(filtered, loadBalancers[i])
+		}
+	}
+
+	return filtered
+}
+
+// loadBalancerMatches reports whether lb matches every filter set in config.
+func loadBalancerMatches(lb *swagger.LoadBalancer, config *loadBalancerDataSourceModel) bool {
+	switch {
+	case config.ID != nil && lb.Id != *config.ID,
+		config.Name != nil && lb.Name != *config.Name,
+		config.Location != nil && lb.Location != *config.Location,
+		config.Type != nil && lb.Type_ != *config.Type:
+		return false
+	case config.Network != nil && !slices.ContainsFunc(lb.NetworkInterfaces, func(n swagger.LoadBalancerNetworkInterface) bool {
+		return n.Network == *config.Network
+	}):
+		return false
+	case config.DestinationResourceID != nil && !slices.ContainsFunc(lb.Destinations, func(d swagger.NetworkTarget) bool {
+		return d.ResourceId == *config.DestinationResourceID
+	}):
+		return false
+	}
+
+	return true
+}
+
+// loadBalancerLookupCriteria describes the id or name lookup in config for SelectSingle errors.
+// One of the two must be set.
+func loadBalancerLookupCriteria(config *loadBalancerDataSourceModel) string {
+	var criteria string
+	if config.ID != nil {
+		criteria = fmt.Sprintf("id %q", *config.ID)
+	} else {
+		criteria = fmt.Sprintf("name %q", *config.Name)
+	}
+	if config.Network != nil || config.Location != nil || config.Type != nil || config.DestinationResourceID != nil {
+		criteria += " and the given filters"
+	}
+
+	return criteria
+}
+
 func loadBalancerNetworkInterfacesToTerraformResourceModel(networkInterfaces []swagger.LoadBalancerNetworkInterface,
 ) (lbNetworkInterfaces types.List, diags diag.Diagnostics) {
 	interfaces := make([]loadBalancerNetworkInterfaceModel, 0, len(networkInterfaces))
@@ -193,16 +301,47 @@ func loadBalancerIPsToTerraformResourceModel(ips []swagger.IpAddresses,
 func loadBalancerHealthCheckToTerraformResourceModel(healthCheck *swagger.HealthCheckOptions,
 ) (lbHealthCheck *healthCheckOptionsResourceModel) {
 	lbHealthCheck = &healthCheckOptionsResourceModel{
-		Timeout:      types.StringValue(healthCheck.Timeout),
-		Port:         types.StringValue(healthCheck.Port),
-		Interval:     types.StringValue(healthCheck.Interval),
-		SuccessCount: types.StringValue(healthCheck.SuccessCount),
-		FailureCount: types.StringValue(healthCheck.FailureCount),
+		Timeout:      parseHealthCheckValue(healthCheck.Timeout),
+		Port:         parseHealthCheckValue(healthCheck.Port),
+		Interval:     parseHealthCheckValue(healthCheck.Interval),
+		SuccessCount: parseHealthCheckValue(healthCheck.SuccessCount),
+		FailureCount: parseHealthCheckValue(healthCheck.FailureCount),
 	}
 
 	return lbHealthCheck
 }
 
+// parseHealthCheckValue converts a health check option from the API, which models every option
+// as a string, to an integer. Durations may carry a trailing "s" for seconds. Values which can't
+// be parsed become null rather than failing the read.
+func parseHealthCheckValue(value string) types.Int64 {
+	parsed, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimSpace(value), "s"), 10, 64)
+	if err != nil {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(parsed)
+}
+
+// formatHealthCheckValue converts a health check option to the string form the API expects,
+// leaving null or unknown values empty so the API picks them.
+func formatHealthCheckValue(value types.Int64) string {
+	if value.IsNull() || value.IsUnknown() {
+		return ""
+	}
+
+	return strconv.FormatInt(value.ValueInt64(), 10)
+}
+
+// formatHealthCheckSeconds converts a health check duration to the API's form, e.g. "30s".
+func formatHealthCheckSeconds(value types.Int64) string {
+	if formatted := formatHealthCheckValue(value); formatted != "" {
+		return formatted + "s"
+	}
+
+	return ""
+}
+
 // healthCheckToSwagger decodes the health_check object attribute into a swagger
 // HealthCheckOptions request payload, returning nil when it is null or unknown.
 // The attribute must be decoded into the tfsdk-tagged resource model first — the
@@ -217,21 +356,128 @@ func healthCheckToSwagger(ctx context.Context, obj types.Object, diags *diag.Dia
 	diags.Append(obj.As(ctx, &model, basetypes.ObjectAsOptions{})...)
 
 	return &swagger.HealthCheckOptions{
-		Timeout:      model.Timeout.ValueString(),
-		Port:         model.Port.ValueString(),
-		Interval:     model.Interval.ValueString(),
-		SuccessCount: model.SuccessCount.ValueString(),
-		FailureCount: model.FailureCount.ValueString(),
+		Timeout:      formatHealthCheckSeconds(model.Timeout),
+		Port:         formatHealthCheckValue(model.Port),
+		Interval:     formatHealthCheckSeconds(model.Interval),
+		SuccessCount: formatHealthCheckValue(model.SuccessCount),
+		FailureCount: formatHealthCheckValue(model.FailureCount),
+	}
+}
+
+// validateHealthCheckTiming checks that a health check times out before the next one is due.
+// Options left out of the config are compared using their defaults, and unknown options are
+// skipped until they are known.
+func validateHealthCheckTiming(model *healthCheckOptionsResourceModel) error {
+	if model.Timeout.IsUnknown() || model.Interval.IsUnknown() {
+		return nil
+	}
+
+	timeout := int64(defaultHealthCheckTimeout)
+	if !model.Timeout.IsNull() {
+		timeout = model.Timeout.ValueInt64()
+	}
+	interval := int64(defaultHealthCheckInterval)
+	if !model.Interval.IsNull() {
+		interval = model.Interval.ValueInt64()
+	}
+
+	if timeout >= interval {
+		return fmt.Errorf("timeout (%ds) must be less than interval (%ds)", timeout, interval)
 	}
+
+	return nil
+}
+
+// splitGroupDestinations separates the destinations targeting one of groupInstanceIDs from the
+// statically configured ones. A destination whose resource ID is in staticResourceIDs stays
+// static even when its instance is also in the group, and is reported as a member as well.
+func splitGroupDestinations(targets []swagger.NetworkTarget, staticResourceIDs, groupInstanceIDs []string,
+) (static []swagger.NetworkTarget, members []string) {
+	static = make([]swagger.NetworkTarget, 0, len(targets))
+	members = make([]string, 0)
+	for _, target := range targets {
+		inGroup := target.ResourceId != "" && slices.Contains(groupInstanceIDs, target.ResourceId)
+		if inGroup {
+			members = append(members, target.ResourceId)
+		}
+		if inGroup && !slices.Contains(staticResourceIDs, target.ResourceId) {
+			continue
+		}
+		static = append(static, target)
+	}
+	slices.Sort(members)
+
+	return static, slices.Compact(members)
+}
+
+// appendGroupDestinations adds a destination for each instance in the group which isn't already
+// a static destination.
+func appendGroupDestinations(static []swagger.NetworkTarget, groupInstanceIDs []string) []swagger.NetworkTarget {
+	destinations := slices.Clone(static)
+	for _, instanceID := range groupInstanceIDs {
+		if !slices.ContainsFunc(destinations, func(target swagger.NetworkTarget) bool { return target.ResourceId == instanceID }) {
+			destinations = append(destinations, swagger.NetworkTarget{ResourceId: instanceID})
+		}
+	}
+
+	return destinations
 }
 
-func loadBalancerUpdateTerraformState(ctx context.Context, lb *swagger.LoadBalancer, state *loadBalancerResourceModel) {
+// instanceGroupActiveInstanceIDs returns the sorted IDs of the instance group's active instances.
+func instanceGroupActiveInstanceIDs(ctx context.Context, apiClient *swagger.APIClient, projectID, groupID string) ([]string, error) {
+	instanceGroup, httpResp, err := apiClient.InstanceGroupsApi.GetInstanceGroup(ctx, groupID, projectID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return nil, common.UnpackAPIError(err)
+	}
+
+	instanceIDs := append([]string{}, instanceGroup.ActiveInstances...)
+	slices.Sort(instanceIDs)
+
+	return instanceIDs, nil
+}
+
+// configuredResourceIDs returns the resource IDs of the destinations in a destinations list.
+func configuredResourceIDs(ctx context.Context, destinations types.List) []string {
+	if destinations.IsNull() || destinations.IsUnknown() {
+		return nil
+	}
+
+	var targets []loadBalancerNetworkTargetModel
+	if diags := destinations.ElementsAs(ctx, &targets, true); diags.HasError() {
+		return nil
+	}
+
+	resourceIDs := make([]string, 0, len(targets))
+	for _, target := range targets {
+		if !target.ResourceID.IsNull() && target.ResourceID.ValueString() != "" {
+			resourceIDs = append(resourceIDs, target.ResourceID.ValueString())
+		}
+	}
+
+	return resourceIDs
+}
+
+// loadBalancerUpdateTerraformState copies lb into state. Destinations targeting one of
+// groupInstanceIDs are reported in destination_instance_ids, and are only kept in destinations
+// when they are configured there as well.
+func loadBalancerUpdateTerraformState(ctx context.Context, lb *swagger.LoadBalancer, groupInstanceIDs []string, state *loadBalancerResourceModel) {
 	state.ID = types.StringValue(lb.Id)
 	state.Name = types.StringValue(lb.Name)
 	state.Location = types.StringValue(lb.Location)
 	state.Algorithm = types.StringValue(lb.Algorithm)
 	state.Type = types.StringValue(lb.Type_)
-	state.Destinations, _ = loadBalancerDestinationsToTerraformResourceModel(lb.Destinations)
+	static, members := splitGroupDestinations(lb.Destinations, configuredResourceIDs(ctx, state.Destinations), groupInstanceIDs)
+	if len(static) > 0 || !state.Destinations.IsNull() {
+		state.Destinations, _ = loadBalancerDestinationsToTerraformResourceModel(static)
+	}
+	if state.DestinationInstanceGroupID.IsNull() {
+		state.DestinationInstanceIDs = types.ListNull(types.StringType)
+	} else {
+		state.DestinationInstanceIDs, _ = types.ListValueFrom(ctx, types.StringType, members)
+	}
 	protocols, _ := types.ListValueFrom(context.Background(), types.StringType, lb.Protocols)
 	state.Protocols = protocols
 	networkInterfaces, _ := loadBalancerNetworkInterfacesToTerraformResourceModel(lb.NetworkInterfaces)
@@ -2,10 +2,13 @@ package load_balancer
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
 )
 
 // TestHealthCheckToSwagger_NullUnknownReturnNil checks that a null or unknown
@@ -29,15 +32,16 @@ func TestHealthCheckToSwagger_NullUnknownReturnNil(t *testing.T) {
 }
 
 // TestHealthCheckToSwagger_Values checks that a populated health_check is decoded
-// into the swagger payload with unquoted values.
+// into the swagger payload in the API's form, with durations in seconds.
 func TestHealthCheckToSwagger_Values(t *testing.T) {
-	obj, d := types.ObjectValueFrom(context.Background(), loadBalancerHealthCheckSchema.AttrTypes, healthCheckOptionsResourceModel{
-		Timeout:      types.StringValue("30s"),
-		Port:         types.StringValue("8080"),
-		Interval:     types.StringValue("10s"),
-		SuccessCount: types.StringValue("3"),
-		FailureCount: types.StringValue("2"),
-	})
+	want := healthCheckOptionsResourceModel{
+		Timeout:      types.Int64Value(30),
+		Port:         types.Int64Value(8080),
+		Interval:     types.Int64Value(10),
+		SuccessCount: types.Int64Value(3),
+		FailureCount: types.Int64Value(2),
+	}
+	obj, d := types.ObjectValueFrom(context.Background(), loadBalancerHealthCheckSchema.AttrTypes, want)
 	if d.HasError() {
 		t.Fatalf("building object: %v", d)
 	}
@@ -59,7 +63,189 @@ func TestHealthCheckToSwagger_Values(t *testing.T) {
 		"failure_count": {got.FailureCount, "2"},
 	} {
 		if tc.got != tc.want {
-			t.Errorf("%s = %q, want %q (unquoted)", field, tc.got, tc.want)
+			t.Errorf("%s = %q, want %q", field, tc.got, tc.want)
+		}
+	}
+
+	// Reading the payload back must give the configured options, or every apply would show a diff.
+	if roundTrip := loadBalancerHealthCheckToTerraformResourceModel(got); *roundTrip != want {
+		t.Errorf("round trip = %+v, want %+v", *roundTrip, want)
+	}
+}
+
+// TestHealthCheckToSwagger_Unset checks that options which aren't known yet are left empty.
+func TestHealthCheckToSwagger_Unset(t *testing.T) {
+	obj, d := types.ObjectValueFrom(context.Background(), loadBalancerHealthCheckSchema.AttrTypes, healthCheckOptionsResourceModel{
+		Timeout:      types.Int64Unknown(),
+		Port:         types.Int64Unknown(),
+		Interval:     types.Int64Null(),
+		SuccessCount: types.Int64Value(3),
+		FailureCount: types.Int64Value(2),
+	})
+	if d.HasError() {
+		t.Fatalf("building object: %v", d)
+	}
+
+	var diags diag.Diagnostics
+	got := healthCheckToSwagger(context.Background(), obj, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got.Timeout != "" || got.Port != "" || got.Interval != "" {
+		t.Errorf("unset options = %q, %q, %q, want them empty", got.Timeout, got.Port, got.Interval)
+	}
+}
+
+func TestParseHealthCheckValue(t *testing.T) {
+	for value, want := range map[string]types.Int64{
+		"8080": types.Int64Value(8080),
+		"30s":  types.Int64Value(30),
+		" 3 ":  types.Int64Value(3),
+		"":     types.Int64Null(),
+		"abc":  types.Int64Null(),
+	} {
+		if got := parseHealthCheckValue(value); !got.Equal(want) {
+			t.Errorf("parseHealthCheckValue(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestValidateHealthCheckTiming(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeout  types.Int64
+		interval types.Int64
+		wantErr  bool
+	}{
+		{name: "timeout below interval", timeout: types.Int64Value(2), interval: types.Int64Value(5)},
+		{name: "both defaulted", timeout: types.Int64Null(), interval: types.Int64Null()},
+		{name: "timeout equals interval", timeout: types.Int64Value(5), interval: types.Int64Value(5), wantErr: true},
+		{name: "timeout above default interval", timeout: types.Int64Value(30), interval: types.Int64Null(), wantErr: true},
+		{name: "unknown interval", timeout: types.Int64Value(30), interval: types.Int64Unknown()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHealthCheckTiming(&healthCheckOptionsResourceModel{Timeout: tt.timeout, Interval: tt.interval})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateHealthCheckTiming() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitGroupDestinations(t *testing.T) {
+	targets := []swagger.NetworkTarget{
+		{Cidr: "10.0.0.5/32"},
+		{ResourceId: "vm-2"},
+		{ResourceId: "vm-static"},
+		{ResourceId: "vm-1"},
+	}
+
+	static, members := splitGroupDestinations(targets, nil, []string{"vm-1", "vm-2", "vm-3"})
+
+	if want := []swagger.NetworkTarget{{Cidr: "10.0.0.5/32"}, {ResourceId: "vm-static"}}; !reflect.DeepEqual(static, want) {
+		t.Errorf("static = %+v, want %+v", static, want)
+	}
+	if want := []string{"vm-1", "vm-2"}; !reflect.DeepEqual(members, want) {
+		t.Errorf("members = %v, want %v", members, want)
+	}
+
+	// A configured destination which is also in the group stays in destinations.
+	static, members = splitGroupDestinations(targets, []string{"vm-static", "vm-1"}, []string{"vm-1", "vm-2", "vm-3"})
+
+	if want := []swagger.NetworkTarget{{Cidr: "10.0.0.5/32"}, {ResourceId: "vm-static"}, {ResourceId: "vm-1"}}; !reflect.DeepEqual(static, want) {
+		t.Errorf("static = %+v, want %+v", static, want)
+	}
+	if want := []string{"vm-1", "vm-2"}; !reflect.DeepEqual(members, want) {
+		t.Errorf("members = %v, want %v", members, want)
+	}
+}
+
+func TestAppendGroupDestinations(t *testing.T) {
+	static := []swagger.NetworkTarget{{Cidr: "10.0.0.5/32"}, {ResourceId: "vm-1"}}
+
+	got := appendGroupDestinations(static, []string{"vm-1", "vm-2"})
+
+	want := []swagger.NetworkTarget{{Cidr: "10.0.0.5/32"}, {ResourceId: "vm-1"}, {ResourceId: "vm-2"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("appendGroupDestinations() = %+v, want %+v", got, want)
+	}
+	if len(static) != 2 {
+		t.Errorf("appendGroupDestinations() modified its input: %+v", static)
+	}
+}
+
+func TestFilterLoadBalancers(t *testing.T) {
+	loadBalancers := []swagger.LoadBalancer{
+		{
+			Id: "lb-1", Name: "web", Location: "us-east1-a", Type_: "internal_ipv4",
+			NetworkInterfaces: []swagger.LoadBalancerNetworkInterface{{Network: "net-1"}},
+			Destinations:      []swagger.NetworkTarget{{ResourceId: "vm-1"}},
+		},
+		{
+			Id: "lb-2", Name: "api", Location: "us-east1-a", Type_: "internal_ipv4",
+			NetworkInterfaces: []swagger.LoadBalancerNetworkInterface{{Network: "net-2"}},
+			Destinations:      []swagger.NetworkTarget{{Cidr: "10.0.0.5/32"}},
+		},
+		{
+			Id: "lb-3", Name: "web", Location: "eu-iceland1-a", Type_: "internal_ipv4",
+			NetworkInterfaces: []swagger.LoadBalancerNetworkInterface{{Network: "net-1"}},
+		},
+	}
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name   string
+		config loadBalancerDataSourceModel
+		want   []string
+	}{
+		{name: "no filters", want: []string{"lb-1", "lb-2", "lb-3"}},
+		{name: "id", config: loadBalancerDataSourceModel{ID: str("lb-2")}, want: []string{"lb-2"}},
+		{name: "name", config: loadBalancerDataSourceModel{Name: str("web")}, want: []string{"lb-1", "lb-3"}},
+		{name: "network", config: loadBalancerDataSourceModel{Network: str("net-2")}, want: []string{"lb-2"}},
+		{name: "name and location", config: loadBalancerDataSourceModel{Name: str("web"), Location: str("eu-iceland1-a")}, want: []string{"lb-3"}},
+		{name: "destination resource", config: loadBalancerDataSourceModel{DestinationResourceID: str("vm-1")}, want: []string{"lb-1"}},
+		{name: "type with no match", config: loadBalancerDataSourceModel{Type: str("external_ipv4")}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, lb := range filterLoadBalancers(loadBalancers, &tt.config) {
+				got = append(got, lb.Id)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterLoadBalancers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicIPv4Addresses(t *testing.T) {
+	ips := []swagger.IpAddresses{
+		{PublicIpv4: &swagger.PublicIpv4Address{Address: "203.0.113.7"}},
+		{PrivateIpv4: &swagger.PrivateIpv4Address{Address: "10.0.0.5"}},
+		{PublicIpv4: &swagger.PublicIpv4Address{Address: "203.0.113.8"}},
+	}
+
+	if got, want := publicIPv4Addresses(ips), []string{"203.0.113.7", "203.0.113.8"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("publicIPv4Addresses() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadBalancerLookupCriteria(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		config loadBalancerDataSourceModel
+		want   string
+	}{
+		{config: loadBalancerDataSourceModel{ID: str("lb-1")}, want: `id "lb-1"`},
+		{config: loadBalancerDataSourceModel{Name: str("web")}, want: `name "web"`},
+		{config: loadBalancerDataSourceModel{Name: str("web"), Location: str("us-east1-a")}, want: `name "web" and the given filters`},
+	}
+	for _, tt := range tests {
+		if got := loadBalancerLookupCriteria(&tt.config); got != tt.want {
+			t.Errorf("loadBalancerLookupCriteria() = %q, want %q", got, tt.want)
 		}
 	}
 }
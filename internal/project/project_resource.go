@@ -20,8 +20,9 @@ type projectResource struct {
 }
 
 type projectResourceModel struct {
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func NewProjectResource() resource.Resource {
@@ -62,6 +63,7 @@ func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Required:            true,
 				MarkdownDescription: apiDescName,
 			},
+			"deletion_protection": common.DeletionProtectionAttribute("project"),
 		},
 	}
 }
@@ -122,6 +124,7 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	projectToResourceModel(&project, &state)
+	state.DeletionProtection = common.DeletionProtectionOrDefault(state.DeletionProtection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -157,8 +160,17 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
+//nolint:gocritic // Implements Terraform defined interface
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	common.ModifyPlanDeletionProtection(ctx, req, resp, "project")
+}
+
 //nolint:gocritic // Implements Terraform defined interface
 func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if common.CheckDeletionProtection(ctx, req.State, "project", &resp.Diagnostics) {
+		return
+	}
+
 	var state projectResourceModel
 	if err := common.GetResourceModel(ctx, req.State, &state, &resp.Diagnostics); err != nil {
 		return
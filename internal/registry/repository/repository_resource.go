@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/antihax/optional"
@@ -29,11 +30,12 @@ type repositoryResource struct {
 }
 
 type repositoryResourceModel struct {
-	ProjectID        types.String                   `tfsdk:"project_id"`
-	Location         types.String                   `tfsdk:"location"`
-	Name             types.String                   `tfsdk:"name"`
-	Mode             types.String                   `tfsdk:"mode"`
-	UpstreamRegistry *upstreamRegistryResourceModel `tfsdk:"upstream_registry"`
+	ProjectID          types.String                   `tfsdk:"project_id"`
+	Location           types.String                   `tfsdk:"location"`
+	Name               types.String                   `tfsdk:"name"`
+	Mode               types.String                   `tfsdk:"mode"`
+	UpstreamRegistry   *upstreamRegistryResourceModel `tfsdk:"upstream_registry"`
+	DeletionProtection types.Bool                     `tfsdk:"deletion_protection"`
 }
 
 type upstreamRegistryResourceModel struct {
@@ -84,6 +86,10 @@ func (r *repositoryResource) Schema(ctx context.Context, _ resource.SchemaReques
 				Computed:            true,
 				Optional:            true,
 				MarkdownDescription: providerDescProjectID,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(), // cannot be updated in place
+				},
 			},
 			"location": schema.StringAttribute{
 				Required:            true,
@@ -130,6 +136,7 @@ func (r *repositoryResource) Schema(ctx context.Context, _ resource.SchemaReques
 					},
 				},
 			},
+			"deletion_protection": common.DeletionProtectionAttribute("repository"),
 		},
 	}
 }
@@ -197,7 +204,7 @@ func (r *repositoryResource) Create(ctx context.Context, request resource.Create
 	}
 
 	// Seed credentials from the plan; repositoryToResourceModel preserves them.
-	state := repositoryResourceModel{UpstreamRegistry: plan.UpstreamRegistry}
+	state := repositoryResourceModel{UpstreamRegistry: plan.UpstreamRegistry, DeletionProtection: plan.DeletionProtection}
 	repositoryToResourceModel(&repository, &state, projectID)
 
 	diags = response.State.Set(ctx, &state)
@@ -236,6 +243,7 @@ func (r *repositoryResource) Read(ctx context.Context, request resource.ReadRequ
 	}
 	// stored carries prior-state credentials, which repositoryToResourceModel preserves.
 	repositoryToResourceModel(&repository, &stored, projectID)
+	stored.DeletionProtection = common.DeletionProtectionOrDefault(stored.DeletionProtection)
 
 	diags = response.State.Set(ctx, &stored)
 	response.Diagnostics.Append(diags...)
@@ -243,14 +251,40 @@ func (r *repositoryResource) Read(ctx context.Context, request resource.ReadRequ
 
 //nolint:gocritic // Implements Terraform defined interface
 func (r *repositoryResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
-	response.Diagnostics.AddError(
-		"Updating Repository Not Supported",
-		"Updating an existing repository is not currently supported. To change its configuration, the repository must be destroyed and recreated.",
-	)
+	var plan, state repositoryResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// deletion_protection is enforced by the provider only, so it is the one attribute that can
+	// change without calling the API.
+	if !reflect.DeepEqual(plan.UpstreamRegistry, state.UpstreamRegistry) {
+		response.Diagnostics.AddError(
+			"Updating Repository Not Supported",
+			"Updating an existing repository is not currently supported. To change its configuration, the repository must be destroyed and recreated.",
+		)
+
+		return
+	}
+
+	state.DeletionProtection = plan.DeletionProtection
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (r *repositoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	common.ModifyPlanDeletionProtection(ctx, req, resp, "repository")
 }
 
 //nolint:gocritic // Implements Terraform defined interface
 func (r *repositoryResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	if common.CheckDeletionProtection(ctx, request.State, "repository", &response.Diagnostics) {
+		return
+	}
+
 	var stored repositoryResourceModel
 	diags := request.State.Get(ctx, &stored)
 	response.Diagnostics.Append(diags...)
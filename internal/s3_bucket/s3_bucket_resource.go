@@ -46,6 +46,7 @@ type s3BucketResourceModel struct {
 	CreatedAt           types.String `tfsdk:"created_at"`
 	UpdatedAt           types.String `tfsdk:"updated_at"`
 	S3URL               types.String `tfsdk:"s3_url"`
+	DeletionProtection  types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func NewS3BucketResource() resource.Resource {
@@ -153,6 +154,7 @@ func (r *s3BucketResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 				MarkdownDescription: apiDescS3URL,
 			},
+			"deletion_protection": common.DeletionProtectionAttribute("S3 bucket"),
 		},
 	}
 }
@@ -268,6 +270,7 @@ func (r *s3BucketResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	s3BucketToTerraformResourceModel(&bucket, &state)
 	state.ProjectID = types.StringValue(projectID)
+	state.DeletionProtection = common.DeletionProtectionOrDefault(state.DeletionProtection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
@@ -387,8 +390,17 @@ func (r *s3BucketResource) Update(ctx context.Context, req resource.UpdateReques
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
+//nolint:gocritic // Implements Terraform defined interface
+func (r *s3BucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	common.ModifyPlanDeletionProtection(ctx, req, resp, "S3 bucket")
+}
+
 //nolint:gocritic // Implements Terraform defined interface
 func (r *s3BucketResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if common.CheckDeletionProtection(ctx, req.State, "S3 bucket", &resp.Diagnostics) {
+		return
+	}
+
 	// Serialize bucket operations to avoid API conflicts
 	bucketMutex.Lock()
 	defer bucketMutex.Unlock()
@@ -0,0 +1,32 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// DurationValidator validates that a given string is a positive Go duration, such as "30s" or "10m".
+type DurationValidator struct{}
+
+func (v DurationValidator) Description(ctx context.Context) string {
+	return "Duration must be a positive number with a unit suffix, e.g. 30s or 10m"
+}
+
+func (v DurationValidator) MarkdownDescription(ctx context.Context) string {
+	return "Duration must be a positive number with a unit suffix, e.g. `30s` or `10m`"
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (v DurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Duration",
+			"Duration must be a positive number with a unit suffix, e.g. 30s or 10m")
+	}
+}
@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+// SSHKeysValidator validates that every entry in a list of SSH public keys is valid, and warns
+// about entries which share a fingerprint with an earlier entry.
+type SSHKeysValidator struct{}
+
+func (v SSHKeysValidator) Description(ctx context.Context) string {
+	return "Each entry must be a valid SSH public key"
+}
+
+func (v SSHKeysValidator) MarkdownDescription(ctx context.Context) string {
+	return "Each entry must be a valid SSH public key"
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (v SSHKeysValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	seen := make(map[string]int)
+	for i, elem := range req.ConfigValue.Elements() {
+		key, ok := elem.(types.String)
+		if !ok || key.IsNull() || key.IsUnknown() {
+			continue
+		}
+
+		fingerprint, err := common.SSHKeyFingerprint(key.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtListIndex(i), "Invalid SSH Key",
+				"The given SSH Key is not a valid public RSA or ECDSA key.")
+
+			continue
+		}
+
+		if first, ok := seen[fingerprint]; ok {
+			resp.Diagnostics.AddAttributeWarning(req.Path.AtListIndex(i), "Duplicate SSH Key",
+				fmt.Sprintf("This key has the same fingerprint (%s) as the key at index %d and will only be added once.", fingerprint, first))
+
+			continue
+		}
+		seen[fingerprint] = i
+	}
+}
@@ -0,0 +1,126 @@
+package vm
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+const networkInterfaceReplaceExplanation = "Network interfaces cannot be added to or removed from an existing VM, " +
+	"and an interface's subnet and private IPv4 address cannot be changed in place, so the VM will be replaced. " +
+	"Changing an interface's public IPv4 type is applied in place."
+
+// networkInterfacesCountRequiresReplace is a listplanmodifier.RequiresReplaceIfFunc which replaces
+// the VM when network interfaces are added or removed.
+//
+//nolint:gocritic // hugeParam: req signature required by listplanmodifier.RequiresReplaceIfFunc
+func networkInterfacesCountRequiresReplace(_ context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	resp.RequiresReplace = len(req.PlanValue.Elements()) != len(req.StateValue.Elements())
+}
+
+// configuredPrivateIPv4 returns the private IPv4 address requested for a network interface, or ""
+// if none is set or it is not yet known.
+func configuredPrivateIPv4(networkInterface *vmNetworkInterfaceResourceModel) string {
+	if networkInterface.PrivateIpv4.IsNull() || networkInterface.PrivateIpv4.IsUnknown() {
+		return ""
+	}
+
+	address, ok := networkInterface.PrivateIpv4.Attributes()["address"].(types.String)
+	if !ok || address.IsNull() || address.IsUnknown() {
+		return ""
+	}
+
+	return address.ValueString()
+}
+
+// privateIPv4Unchanged reports whether prior has an interface at index i with the same subnet and
+// private IPv4 address as networkInterface, so the address was already accepted.
+func privateIPv4Unchanged(prior []vmNetworkInterfaceResourceModel, i int, networkInterface *vmNetworkInterfaceResourceModel) bool {
+	if i >= len(prior) {
+		return false
+	}
+
+	return prior[i].Subnet.Equal(networkInterface.Subnet) && configuredPrivateIPv4(&prior[i]) == configuredPrivateIPv4(networkInterface)
+}
+
+// checkPrivateIPv4InCIDR returns an error if address is not a valid IPv4 address within cidr.
+func checkPrivateIPv4InCIDR(address, cidr string) error {
+	addr, err := netip.ParseAddr(address)
+	if err != nil || !addr.Is4() {
+		return fmt.Errorf("%q is not a valid IPv4 address", address)
+	}
+
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return fmt.Errorf("subnet CIDR %q could not be parsed: %w", cidr, err)
+	}
+
+	if !prefix.Contains(addr) {
+		return fmt.Errorf("%s is not within the subnet CIDR %s", address, cidr)
+	}
+
+	return nil
+}
+
+// validateNetworkInterfaces checks each requested private IPv4 address against the CIDR of the
+// interface's subnet. Interfaces whose subnet or address is not yet known are skipped, and so are
+// interfaces whose subnet and address are the same as in priorNetworkInterfaces, the VM's state.
+func validateNetworkInterfaces(ctx context.Context, apiClient *swagger.APIClient, projectID string,
+	networkInterfaces, priorNetworkInterfaces types.List, diags *diag.Diagnostics,
+) {
+	if networkInterfaces.IsNull() || networkInterfaces.IsUnknown() {
+		return
+	}
+
+	tNetworkInterfaces := make([]vmNetworkInterfaceResourceModel, 0, len(networkInterfaces.Elements()))
+	if d := networkInterfaces.ElementsAs(ctx, &tNetworkInterfaces, true); d.HasError() {
+		// unknown nested values can't be converted yet; they are checked again on apply
+		return
+	}
+
+	var priorInterfaces []vmNetworkInterfaceResourceModel
+	if !priorNetworkInterfaces.IsNull() && !priorNetworkInterfaces.IsUnknown() {
+		// an unreadable prior value only means every interface is checked
+		_ = priorNetworkInterfaces.ElementsAs(ctx, &priorInterfaces, true)
+	}
+
+	for i := range tNetworkInterfaces {
+		address := configuredPrivateIPv4(&tNetworkInterfaces[i])
+		subnetID := tNetworkInterfaces[i].Subnet
+		if address == "" || subnetID.IsNull() || subnetID.IsUnknown() {
+			continue
+		}
+		if privateIPv4Unchanged(priorInterfaces, i, &tNetworkInterfaces[i]) {
+			continue
+		}
+
+		attrPath := path.Root("network_interfaces").AtListIndex(i).AtName("private_ipv4").AtName("address")
+		subnet, httpResp, err := apiClient.VPCSubnetsApi.GetVPCSubnet(ctx, projectID, subnetID.ValueString())
+		if httpResp != nil {
+			httpResp.Body.Close()
+		}
+		if err != nil {
+			diags.AddAttributeError(attrPath, "Failed to validate private IPv4 address",
+				fmt.Sprintf("There was an error fetching subnet %s: %s", subnetID.ValueString(), common.UnpackAPIError(err)))
+
+			continue
+		}
+
+		if err := checkPrivateIPv4InCIDR(address, subnet.Cidr); err != nil {
+			diags.AddAttributeError(attrPath, "Invalid private IPv4 address", err.Error())
+		}
+	}
+}
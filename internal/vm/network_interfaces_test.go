@@ -0,0 +1,102 @@
+package vm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_checkPrivateIPv4InCIDR(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		cidr    string
+		wantErr bool
+	}{
+		{name: "within subnet", address: "10.0.0.5", cidr: "10.0.0.0/24"},
+		{name: "outside subnet", address: "10.0.1.5", cidr: "10.0.0.0/24", wantErr: true},
+		{name: "not an address", address: "10.0.0", cidr: "10.0.0.0/24", wantErr: true},
+		{name: "IPv6 address", address: "fd00::1", cidr: "10.0.0.0/24", wantErr: true},
+		{name: "invalid CIDR", address: "10.0.0.5", cidr: "10.0.0.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPrivateIPv4InCIDR(tt.address, tt.cidr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkPrivateIPv4InCIDR() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_networkInterfacesCountRequiresReplace(t *testing.T) {
+	ctx := context.Background()
+	nic := types.ObjectUnknown(vmNetworkInterfaceSchema.AttrTypes)
+	listOf := func(n int) types.List {
+		elems := make([]attr.Value, n)
+		for i := range elems {
+			elems[i] = nic
+		}
+
+		return types.ListValueMust(vmNetworkInterfaceSchema, elems)
+	}
+
+	tests := []struct {
+		name  string
+		state types.List
+		plan  types.List
+		want  bool
+	}{
+		{name: "same count", state: listOf(1), plan: listOf(1), want: false},
+		{name: "interface added", state: listOf(1), plan: listOf(2), want: true},
+		{name: "interface removed", state: listOf(2), plan: listOf(1), want: true},
+		{name: "create", state: types.ListNull(vmNetworkInterfaceSchema), plan: listOf(2), want: false},
+		{name: "unknown plan", state: listOf(1), plan: types.ListUnknown(vmNetworkInterfaceSchema), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &listplanmodifier.RequiresReplaceIfFuncResponse{}
+			networkInterfacesCountRequiresReplace(ctx, planmodifier.ListRequest{StateValue: tt.state, PlanValue: tt.plan}, resp)
+			if resp.RequiresReplace != tt.want {
+				t.Errorf("RequiresReplace = %v, want %v", resp.RequiresReplace, tt.want)
+			}
+		})
+	}
+}
+
+func Test_privateIPv4Unchanged(t *testing.T) {
+	nic := func(subnet, address string) vmNetworkInterfaceResourceModel {
+		return vmNetworkInterfaceResourceModel{
+			Subnet: types.StringValue(subnet),
+			PrivateIpv4: types.ObjectValueMust(map[string]attr.Type{"address": types.StringType},
+				map[string]attr.Value{"address": types.StringValue(address)}),
+		}
+	}
+	prior := []vmNetworkInterfaceResourceModel{nic("subnet-1", "10.0.0.5")}
+
+	tests := []struct {
+		name    string
+		index   int
+		current vmNetworkInterfaceResourceModel
+		want    bool
+	}{
+		{name: "unchanged", index: 0, current: nic("subnet-1", "10.0.0.5"), want: true},
+		{name: "address changed", index: 0, current: nic("subnet-1", "10.0.0.6"), want: false},
+		{name: "subnet changed", index: 0, current: nic("subnet-2", "10.0.0.5"), want: false},
+		{name: "new interface", index: 1, current: nic("subnet-1", "10.0.0.5"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := privateIPv4Unchanged(prior, tt.index, &tt.current); got != tt.want {
+				t.Errorf("privateIPv4Unchanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
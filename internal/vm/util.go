@@ -20,6 +20,9 @@ const (
 	StateRunning = "STATE_RUNNING"
 	StateStopped = "STATE_STOPPED"
 	StateShutoff = "STATE_SHUTOFF"
+
+	ActionStop  = "STOP"
+	ActionStart = "START"
 )
 
 // apiDesc* — schema descriptions derived from the client-go swagger spec (InstanceV1).
@@ -76,6 +79,12 @@ const (
 	// the spec text; the field is deprecated and its behavior is provider-specific.
 	providerDescReservationID = "ID of the reservation to which the VM belongs. If not provided or null, the lowest-cost reservation will be used by default. To opt out of using a reservation, set this to an empty string."
 	providerDescIBPartitionID = "Infiniband Partition ID."
+	providerDescSSHKeys       = "SSH public keys to grant access to the new VM, in addition to `ssh_key`. " +
+		"Keys with the same fingerprint are only added once. At least one of `ssh_key` or `ssh_keys` must be set."
+	providerDescPrivateIpv4Address = apiDescPrivateIpv4Address + " May be set to request a specific address within the subnet's CIDR; " +
+		"otherwise one is assigned. Changing it replaces the VM."
+	providerDescRebootTriggers = "Arbitrary map of values which, when changed, cause the VM to be stopped and started again " +
+		"in place instead of being replaced. A stopped VM is not started. Adding or removing the map does not reboot the VM."
 )
 
 // instanceTypeFamily returns the product-family prefix of an instance type,
@@ -177,6 +186,42 @@ func getVM(ctx context.Context, apiClient *swagger.APIClient, projectID, vmID st
 	return &dataResp, nil
 }
 
+// SetInstancePowerState issues a STOP or START action against the VM and waits for it to complete.
+func SetInstancePowerState(ctx context.Context, apiClient *swagger.APIClient, projectID, vmID, action string) error {
+	patchResp, httpResp, err := apiClient.VMsApi.UpdateInstance(ctx, swagger.InstancesPatchRequestV1{
+		Action: action,
+	}, projectID, vmID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return common.UnpackAPIError(err)
+	}
+
+	_, err = common.AwaitOperation(ctx, patchResp.Operation, projectID, apiClient.VMOperationsApi.GetComputeVMsInstancesOperation)
+	if err != nil {
+		return common.UnpackAPIError(err)
+	}
+
+	return nil
+}
+
+// rebootRequired reports whether a change to reboot_triggers should stop and start the VM.
+// Adding or removing reboot_triggers altogether only records the new value, and a resize in
+// the same apply has already restarted the VM.
+func rebootRequired(priorTriggers, plannedTriggers types.Map, resized bool) bool {
+	if resized || priorTriggers.IsNull() || plannedTriggers.IsNull() {
+		return false
+	}
+
+	return !plannedTriggers.Equal(priorTriggers)
+}
+
+// IsInstanceStopped reports whether the VM is in a powered-off state.
+func IsInstanceStopped(instance *swagger.InstanceV1) bool {
+	return instance.State == StateStopped || instance.State == StateShutoff
+}
+
 // vmNetworkInterfacesToTerraformDataModel creates a slice of Terraform-compatible network
 // interface datasource instances from Crusoe API network interfaces.
 //
@@ -264,6 +309,21 @@ func vmHostChannelAdaptersToTerraformResourceModel(hostChannelAdapters []swagger
 	return values
 }
 
+// hostChannelAdapterPartitionID returns the IB partition of the first host channel adapter, or ""
+// when there is none or it isn't known yet.
+func hostChannelAdapterPartitionID(ctx context.Context, hostChannelAdapters types.List) string {
+	if hostChannelAdapters.IsNull() || hostChannelAdapters.IsUnknown() {
+		return ""
+	}
+
+	var hcas []vmHostChannelAdapterResourceModel
+	if diags := hostChannelAdapters.ElementsAs(ctx, &hcas, true); diags.HasError() || len(hcas) == 0 {
+		return ""
+	}
+
+	return hcas[0].IBPartitionID
+}
+
 func findInstance(ctx context.Context, client *swagger.APIClient, instanceID string) (*swagger.InstanceV1, error) {
 	opts := &swagger.ProjectsApiListProjectsOpts{
 		OrgId: optional.EmptyString(),
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -246,3 +247,58 @@ func Test_resizeRequiresReplace(t *testing.T) {
 		})
 	}
 }
+
+func Test_rebootRequired(t *testing.T) {
+	triggers := func(values map[string]string) types.Map {
+		elements := make(map[string]attr.Value, len(values))
+		for k, v := range values {
+			elements[k] = types.StringValue(v)
+		}
+
+		return types.MapValueMust(types.StringType, elements)
+	}
+	null := types.MapNull(types.StringType)
+
+	tests := []struct {
+		name    string
+		prior   types.Map
+		planned types.Map
+		resized bool
+		want    bool
+	}{
+		{name: "value changed", prior: triggers(map[string]string{"config": "a"}), planned: triggers(map[string]string{"config": "b"}), want: true},
+		{name: "key added", prior: triggers(map[string]string{"config": "a"}), planned: triggers(map[string]string{"config": "a", "driver": "1"}), want: true},
+		{name: "key removed", prior: triggers(map[string]string{"config": "a", "driver": "1"}), planned: triggers(map[string]string{"config": "a"}), want: true},
+		{name: "emptied", prior: triggers(map[string]string{"config": "a"}), planned: triggers(map[string]string{}), want: true},
+		{name: "unchanged", prior: triggers(map[string]string{"config": "a"}), planned: triggers(map[string]string{"config": "a"}), want: false},
+		{name: "map added", prior: null, planned: triggers(map[string]string{"config": "a"}), want: false},
+		{name: "map removed", prior: triggers(map[string]string{"config": "a"}), planned: null, want: false},
+		{name: "never set", prior: null, planned: null, want: false},
+		{name: "already restarted by a resize", prior: triggers(map[string]string{"config": "a"}), planned: triggers(map[string]string{"config": "b"}), resized: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rebootRequired(tt.prior, tt.planned, tt.resized); got != tt.want {
+				t.Errorf("rebootRequired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_IsInstanceStopped covers which VMs a reboot or resize stops and starts again: a VM which
+// is already powered off is left off.
+func Test_IsInstanceStopped(t *testing.T) {
+	tests := map[string]bool{
+		StateRunning:     false,
+		StateStopped:     true,
+		StateShutoff:     true,
+		"STATE_STARTING": false,
+	}
+
+	for state, want := range tests {
+		if got := IsInstanceStopped(&swagger.InstanceV1{State: state}); got != want {
+			t.Errorf("IsInstanceStopped(%q) = %v, want %v", state, got, want)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package vm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+	validators "github.com/crusoecloud/terraform-provider-crusoe/internal/validators"
+)
+
+const (
+	overrideDiskTypePersistentSSD = "persistent-ssd"
+	overrideDiskTypeSharedVolume  = "shared-volume"
+	overridePublicIPStatic        = "static"
+	overridePublicIPDynamic       = "dynamic"
+
+	providerDescOverrides = "Per-instance overrides merged over the instance template when the VM is created. " +
+		"Changing any override replaces the VM, but adding or removing an empty `overrides` block does not. " +
+		"As VMs can only be created from a template, the provider creates a temporary instance template " +
+		"with the overrides applied and deletes it again once the VM has been created."
+	providerDescOverrideSSHKey              = "SSH public key to use instead of the template's."
+	providerDescOverrideStartupScript       = "Startup script to use instead of the template's."
+	providerDescOverrideSubnet              = "ID of the subnet to use instead of the template's."
+	providerDescOverridePublicIPAddressType = "Public IP address type to use instead of the template's. Must either be `static` or `dynamic`."
+	providerDescOverrideIBPartitionID       = "ID of the Infiniband partition to use instead of the template's."
+	providerDescOverrideDisks               = "Additional data disks to create and attach, on top of the template's disks."
+	providerDescOverrideDiskSize            = "Size of the disk, e.g. `100GiB` or `2TiB`."
+	providerDescOverrideDiskType            = "Type of the disk. Must either be `persistent-ssd` or `shared-volume`."
+)
+
+type vmByTemplateOverridesResourceModel struct {
+	SSHKey              types.String `tfsdk:"ssh_key"`
+	StartupScript       types.String `tfsdk:"startup_script"`
+	Subnet              types.String `tfsdk:"subnet"`
+	PublicIPAddressType types.String `tfsdk:"public_ip_address_type"`
+	IBPartitionID       types.String `tfsdk:"ib_partition_id"`
+	Disks               types.Set    `tfsdk:"disks"`
+}
+
+type vmByTemplateDiskOverrideResourceModel struct {
+	Size types.String `tfsdk:"size"`
+	Type types.String `tfsdk:"type"`
+}
+
+var vmByTemplateDiskOverrideSchema = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"size": types.StringType,
+		"type": types.StringType,
+	},
+}
+
+func overridesSchemaBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: providerDescOverrides,
+		// cannot be updated in place, but an empty block applies nothing
+		PlanModifiers: []planmodifier.Object{objectplanmodifier.RequiresReplaceIf(
+			overridesRequiresReplaceIf,
+			"Recreates the VM when the configured overrides change.",
+			"Recreates the VM when the configured overrides change.",
+		)},
+		Attributes: map[string]schema.Attribute{
+			"ssh_key": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescOverrideSSHKey,
+				Validators:          []validator.String{validators.SSHKeyValidator{}},
+			},
+			"startup_script": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescOverrideStartupScript,
+			},
+			"subnet": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescOverrideSubnet,
+			},
+			"public_ip_address_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescOverridePublicIPAddressType,
+				Validators:          []validator.String{stringvalidator.OneOf(overridePublicIPStatic, overridePublicIPDynamic)},
+			},
+			"ib_partition_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescOverrideIBPartitionID,
+			},
+			"disks": schema.SetNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: providerDescOverrideDisks,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"size": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: providerDescOverrideDiskSize,
+							Validators:          []validator.String{validators.StorageSizeValidator{}},
+						},
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: providerDescOverrideDiskType,
+							Validators:          []validator.String{stringvalidator.OneOf(overrideDiskTypePersistentSSD, overrideDiskTypeSharedVolume)},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// hasOverrides reports whether any override is configured. An empty overrides block is
+// treated the same as no block, so the template is used as-is. Values which are not yet
+// known count as configured.
+func hasOverrides(o *vmByTemplateOverridesResourceModel) bool {
+	isSet := func(v types.String) bool {
+		return v.IsUnknown() || v.ValueString() != ""
+	}
+
+	return isSet(o.SSHKey) ||
+		isSet(o.StartupScript) ||
+		isSet(o.Subnet) ||
+		isSet(o.PublicIPAddressType) ||
+		isSet(o.IBPartitionID) ||
+		o.Disks.IsUnknown() || len(o.Disks.Elements()) > 0
+}
+
+// overridesRequiresReplaceIf only replaces the VM when the effective overrides change, so going
+// between no overrides block and an empty one is not a change.
+func overridesRequiresReplaceIf(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+	stateOverrides := getOverrides(ctx, req.StateValue, &resp.Diagnostics)
+	planOverrides := getOverrides(ctx, req.PlanValue, &resp.Diagnostics)
+	if stateOverrides == nil && planOverrides == nil {
+		return
+	}
+
+	resp.RequiresReplace = true
+}
+
+// getOverrides reads the overrides block, returning nil if none is configured.
+func getOverrides(ctx context.Context, overridesObj types.Object, diags *diag.Diagnostics) *vmByTemplateOverridesResourceModel {
+	if overridesObj.IsNull() || overridesObj.IsUnknown() {
+		return nil
+	}
+
+	var overrides vmByTemplateOverridesResourceModel
+	diags.Append(overridesObj.As(ctx, &overrides, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() || !hasOverrides(&overrides) {
+		return nil
+	}
+
+	return &overrides
+}
+
+// applyTemplateOverrides returns a copy of template with the configured overrides merged over it.
+// Scalar overrides replace the template's value; override disks are added to the template's disks.
+func applyTemplateOverrides(ctx context.Context, template *swagger.InstanceTemplate,
+	overrides *vmByTemplateOverridesResourceModel,
+) (swagger.InstanceTemplate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	merged := *template
+	merged.Disks = append([]swagger.DiskTemplate(nil), template.Disks...)
+
+	if v := overrides.SSHKey.ValueString(); v != "" {
+		merged.SshPublicKey = v
+	}
+	if v := overrides.StartupScript.ValueString(); v != "" {
+		merged.StartupScript = v
+	}
+	if v := overrides.Subnet.ValueString(); v != "" {
+		merged.SubnetId = v
+	}
+	if v := overrides.PublicIPAddressType.ValueString(); v != "" {
+		merged.PublicIpAddressType = v
+	}
+	if v := overrides.IBPartitionID.ValueString(); v != "" {
+		merged.IbPartitionId = v
+	}
+
+	if !overrides.Disks.IsNull() && !overrides.Disks.IsUnknown() {
+		tDisks := make([]vmByTemplateDiskOverrideResourceModel, 0, len(overrides.Disks.Elements()))
+		diags.Append(overrides.Disks.ElementsAs(ctx, &tDisks, true)...)
+		for _, d := range tDisks {
+			merged.Disks = append(merged.Disks, swagger.DiskTemplate{
+				Size:  d.Size.ValueString(),
+				Type_: d.Type.ValueString(),
+			})
+		}
+	}
+
+	return merged, diags
+}
+
+// createOverrideTemplate creates a single-use instance template holding the merged configuration,
+// since the bulk create API only instantiates VMs from a template. The caller is responsible for
+// deleting it with cleanupOverrideTemplate once the create operation has finished.
+func createOverrideTemplate(ctx context.Context, apiClient *swagger.APIClient, projectID, namePrefix string,
+	merged *swagger.InstanceTemplate,
+) (string, error) {
+	dataResp, httpResp, err := apiClient.InstanceTemplatesApi.CreateInstanceTemplate(ctx, swagger.InstanceTemplatePostRequestV1{
+		TemplateName:        fmt.Sprintf("%s-overrides-%s", namePrefix, uuid.NewString()[:8]),
+		Type_:               merged.Type_,
+		Location:            merged.Location,
+		ImageName:           merged.ImageName,
+		SshPublicKey:        merged.SshPublicKey,
+		StartupScript:       merged.StartupScript,
+		ShutdownScript:      merged.ShutdownScript,
+		SubnetId:            merged.SubnetId,
+		IbPartitionId:       merged.IbPartitionId,
+		Disks:               merged.Disks,
+		PublicIpAddressType: merged.PublicIpAddressType,
+		PlacementPolicy:     merged.PlacementPolicy,
+		NvlinkDomainId:      merged.NvlinkDomainId,
+	}, projectID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return "", common.UnpackAPIError(err)
+	}
+
+	return dataResp.Id, nil
+}
+
+// cleanupOverrideTemplate deletes the single-use template created by createOverrideTemplate, if
+// any. A template which cannot be deleted is reported as an error, as it would otherwise be left
+// behind in the project unnoticed.
+func cleanupOverrideTemplate(ctx context.Context, apiClient *swagger.APIClient, projectID, templateID string, diags *diag.Diagnostics) {
+	if templateID == "" {
+		return
+	}
+
+	if err := deleteOverrideTemplate(ctx, apiClient, projectID, templateID); err != nil {
+		diags.AddError("Failed to clean up instance template",
+			fmt.Sprintf("The instance template %s created for the configured overrides could not be deleted and must be removed manually: %s",
+				templateID, err))
+	}
+}
+
+func deleteOverrideTemplate(ctx context.Context, apiClient *swagger.APIClient, projectID, templateID string) error {
+	httpResp, err := apiClient.InstanceTemplatesApi.DeleteInstanceTemplate(ctx, templateID, projectID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return common.UnpackAPIError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package vm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+)
+
+func Test_applyTemplateOverrides(t *testing.T) {
+	ctx := context.Background()
+	template := &swagger.InstanceTemplate{
+		Type_:               "a100.1x",
+		ImageName:           "ubuntu22.04",
+		SshPublicKey:        "template-key",
+		StartupScript:       "template-startup",
+		ShutdownScript:      "template-shutdown",
+		SubnetId:            "template-subnet",
+		PublicIpAddressType: overridePublicIPDynamic,
+		IbPartitionId:       "template-partition",
+		Disks:               []swagger.DiskTemplate{{Size: "100GiB", Type_: overrideDiskTypePersistentSSD}},
+	}
+
+	disks, diags := types.SetValueFrom(ctx, vmByTemplateDiskOverrideSchema, []vmByTemplateDiskOverrideResourceModel{
+		{Size: types.StringValue("1TiB"), Type: types.StringValue(overrideDiskTypeSharedVolume)},
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to build disks: %v", diags)
+	}
+
+	overrides := &vmByTemplateOverridesResourceModel{
+		SSHKey:              types.StringNull(),
+		StartupScript:       types.StringValue("override-startup"),
+		Subnet:              types.StringValue("override-subnet"),
+		PublicIPAddressType: types.StringValue(overridePublicIPStatic),
+		IBPartitionID:       types.StringNull(),
+		Disks:               disks,
+	}
+
+	merged, diags := applyTemplateOverrides(ctx, template, overrides)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if merged.StartupScript != "override-startup" {
+		t.Errorf("StartupScript = %q, want override", merged.StartupScript)
+	}
+	if merged.SubnetId != "override-subnet" {
+		t.Errorf("SubnetId = %q, want override", merged.SubnetId)
+	}
+	if merged.PublicIpAddressType != overridePublicIPStatic {
+		t.Errorf("PublicIpAddressType = %q, want %q", merged.PublicIpAddressType, overridePublicIPStatic)
+	}
+	if merged.SshPublicKey != "template-key" {
+		t.Errorf("SshPublicKey = %q, want the template value when not overridden", merged.SshPublicKey)
+	}
+	if merged.IbPartitionId != "template-partition" {
+		t.Errorf("IbPartitionId = %q, want the template value when not overridden", merged.IbPartitionId)
+	}
+	if merged.ShutdownScript != "template-shutdown" {
+		t.Errorf("ShutdownScript = %q, want the template value", merged.ShutdownScript)
+	}
+	if len(merged.Disks) != 2 || merged.Disks[1].Size != "1TiB" {
+		t.Errorf("Disks = %+v, want the template disk followed by the override disk", merged.Disks)
+	}
+	if len(template.Disks) != 1 {
+		t.Errorf("template disks were modified: %+v", template.Disks)
+	}
+}
+
+func Test_hasOverrides(t *testing.T) {
+	empty := vmByTemplateOverridesResourceModel{
+		SSHKey:              types.StringNull(),
+		StartupScript:       types.StringNull(),
+		Subnet:              types.StringNull(),
+		PublicIPAddressType: types.StringNull(),
+		IBPartitionID:       types.StringNull(),
+		Disks:               types.SetNull(vmByTemplateDiskOverrideSchema),
+	}
+	if hasOverrides(&empty) {
+		t.Error("hasOverrides() = true for an empty block, want false")
+	}
+
+	withSubnet := empty
+	withSubnet.Subnet = types.StringValue("subnet")
+	if !hasOverrides(&withSubnet) {
+		t.Error("hasOverrides() = false with a subnet override, want true")
+	}
+
+	unknownSubnet := empty
+	unknownSubnet.Subnet = types.StringUnknown()
+	if !hasOverrides(&unknownSubnet) {
+		t.Error("hasOverrides() = false with a subnet override that is not yet known, want true")
+	}
+}
+
+func Test_overridesRequiresReplaceIf(t *testing.T) {
+	ctx := context.Background()
+	attrTypes := map[string]attr.Type{
+		"ssh_key":                types.StringType,
+		"startup_script":         types.StringType,
+		"subnet":                 types.StringType,
+		"public_ip_address_type": types.StringType,
+		"ib_partition_id":        types.StringType,
+		"disks":                  types.SetType{ElemType: vmByTemplateDiskOverrideSchema},
+	}
+	block := func(subnet types.String) types.Object {
+		return types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"ssh_key":                types.StringNull(),
+			"startup_script":         types.StringNull(),
+			"subnet":                 subnet,
+			"public_ip_address_type": types.StringNull(),
+			"ib_partition_id":        types.StringNull(),
+			"disks":                  types.SetNull(vmByTemplateDiskOverrideSchema),
+		})
+	}
+
+	tests := []struct {
+		name  string
+		state types.Object
+		plan  types.Object
+		want  bool
+	}{
+		{name: "empty block added", state: types.ObjectNull(attrTypes), plan: block(types.StringNull()), want: false},
+		{name: "empty block removed", state: block(types.StringNull()), plan: types.ObjectNull(attrTypes), want: false},
+		{name: "override added", state: types.ObjectNull(attrTypes), plan: block(types.StringValue("subnet")), want: true},
+		{name: "override removed", state: block(types.StringValue("subnet")), plan: block(types.StringNull()), want: true},
+		{name: "override changed", state: block(types.StringValue("a")), plan: block(types.StringValue("b")), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &objectplanmodifier.RequiresReplaceIfFuncResponse{}
+			overridesRequiresReplaceIf(ctx, planmodifier.ObjectRequest{StateValue: tt.state, PlanValue: tt.plan}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if resp.RequiresReplace != tt.want {
+				t.Errorf("RequiresReplace = %v, want %v", resp.RequiresReplace, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -47,6 +48,8 @@ type vmByTemplateResourceModel struct {
 	ReservationID           types.String `tfsdk:"reservation_id"`
 	NvlinkDomainID          types.String `tfsdk:"nvlink_domain_id"`
 	InstallCrusoeWatchAgent types.Bool   `tfsdk:"install_crusoe_watch_agent"`
+	Overrides               types.Object `tfsdk:"overrides"`
+	WaitFor                 types.Object `tfsdk:"wait_for"`
 }
 
 func NewVMByTemplateResource() resource.Resource {
@@ -241,6 +244,10 @@ func (r *vmByTemplateResource) Schema(ctx context.Context, req resource.SchemaRe
 				Description:   "Whether to install the Crusoe Watch Agent on the VM. Defaults to true.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"overrides": overridesSchemaBlock(),
+			"wait_for":  waitForSchemaBlock(),
+		},
 	}
 }
 
@@ -284,6 +291,32 @@ func (r *vmByTemplateResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	// the bulk create API takes the VM configuration only by instance template ID, so overrides
+	// are merged into a single-use copy of the template which is deleted as soon as the create
+	// operation has finished.
+	overrideTemplateID := ""
+	if overrides := getOverrides(ctx, plan.Overrides, &resp.Diagnostics); overrides != nil {
+		merged, mergeDiags := applyTemplateOverrides(ctx, &instanceTemplateResp, overrides)
+		resp.Diagnostics.Append(mergeDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		overrideTemplateID, err = createOverrideTemplate(ctx, r.client.APIClient, projectID, plan.NamePrefix.ValueString(), &merged)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to create instance",
+				fmt.Sprintf("There was an error creating an instance template with the configured overrides: %s", err))
+
+			return
+		}
+
+		instanceTemplateID = overrideTemplateID
+		instanceTemplateResp = merged
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var installCrusoeWatchAgent *bool
 	if !plan.InstallCrusoeWatchAgent.IsNull() && !plan.InstallCrusoeWatchAgent.IsUnknown() {
 		v := plan.InstallCrusoeWatchAgent.ValueBool()
@@ -302,15 +335,20 @@ func (r *vmByTemplateResource) Create(ctx context.Context, req resource.CreateRe
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create instance",
 			fmt.Sprintf("There was an error starting a create instance operation: %s", common.UnpackAPIError(err)))
+		cleanupOverrideTemplate(ctx, r.client.APIClient, projectID, overrideTemplateID, &resp.Diagnostics)
 
 		return
 	}
 
 	instances, _, err := common.AwaitOperationAndResolve[[]swagger.InstanceV1](
 		ctx, dataResp.Operation, projectID, r.client.APIClient.VMOperationsApi.GetComputeVMsInstancesOperation)
+	// a failed cleanup is only reported once the VM is saved in state, so the VM is not orphaned.
+	var cleanupDiags diag.Diagnostics
+	cleanupOverrideTemplate(ctx, r.client.APIClient, projectID, overrideTemplateID, &cleanupDiags)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create instance",
 			fmt.Sprintf("There was an error creating an instance: %s", common.UnpackAPIError(err)))
+		resp.Diagnostics.Append(cleanupDiags...)
 
 		return
 	}
@@ -318,6 +356,7 @@ func (r *vmByTemplateResource) Create(ctx context.Context, req resource.CreateRe
 	if len(instancesList) < 1 {
 		resp.Diagnostics.AddError("Failed to create instance",
 			"Failed to create instance: no instance was created")
+		resp.Diagnostics.Append(cleanupDiags...)
 
 		return
 	}
@@ -394,8 +433,18 @@ func (r *vmByTemplateResource) Create(ctx context.Context, req resource.CreateRe
 		plan.Disks = diskAttachmentsSet
 	}
 
+	resp.Diagnostics.Append(cleanupDiags...)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// State is saved before waiting so that a VM which never becomes ready is tainted, not orphaned.
+	if err := awaitVMConditions(ctx, r.client.APIClient, projectID, instance.Id, plan.WaitFor); err != nil {
+		resp.Diagnostics.AddError("Instance did not become ready",
+			fmt.Sprintf("The instance was created, but the wait_for conditions were not met: %s", err))
+	}
 }
 
 //nolint:gocritic // Implements Terraform defined interface
@@ -452,6 +501,9 @@ func (r *vmByTemplateResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	state.Overrides = plan.Overrides // only applied on create
+	state.WaitFor = plan.WaitFor     // only checked on create
+
 	// attach/detach disks if requested
 	tPlanDisks := make([]vmDiskResourceModel, 0, len(plan.Disks.Elements()))
 	diags = plan.Disks.ElementsAs(ctx, &tPlanDisks, true)
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -20,6 +22,7 @@ import (
 
 	swagger "github.com/crusoecloud/client-go/swagger/v1"
 	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/ib_network"
 	validators "github.com/crusoecloud/terraform-provider-crusoe/internal/validators"
 )
 
@@ -38,6 +41,7 @@ type vmResourceModel struct {
 	Name                    types.String `tfsdk:"name"`
 	Type                    types.String `tfsdk:"type"`
 	SSHKey                  types.String `tfsdk:"ssh_key"`
+	SSHKeys                 types.List   `tfsdk:"ssh_keys"`
 	Location                types.String `tfsdk:"location"`
 	Image                   types.String `tfsdk:"image"`
 	CustomImage             types.String `tfsdk:"custom_image"`
@@ -52,6 +56,9 @@ type vmResourceModel struct {
 	ReservationID           types.String `tfsdk:"reservation_id"`
 	NvlinkDomainID          types.String `tfsdk:"nvlink_domain_id"`
 	InstallCrusoeWatchAgent types.Bool   `tfsdk:"install_crusoe_watch_agent"`
+	RebootTriggers          types.Map    `tfsdk:"reboot_triggers"`
+	WaitFor                 types.Object `tfsdk:"wait_for"`
+	DeletionProtection      types.Bool   `tfsdk:"deletion_protection"`
 }
 
 type vmNetworkInterfaceResourceModel struct {
@@ -167,10 +174,30 @@ func (r *vmResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 				)},
 			},
 			"ssh_key": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				MarkdownDescription: apiDescSSHKey,
-				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()}, // cannot be updated in place
-				Validators:          []validator.String{validators.SSHKeyValidator{}},
+				// cannot be updated in place, but moving the same key into ssh_keys is not a change
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIf(
+					common.SSHKeyRequiresReplaceIf,
+					"Recreates the VM when the set of SSH keys changes.",
+					"Recreates the VM when the set of SSH keys changes.",
+				)},
+				Validators: []validator.String{
+					validators.SSHKeyValidator{},
+					stringvalidator.AtLeastOneOf(path.MatchRoot("ssh_keys")),
+				},
+			},
+			"ssh_keys": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: providerDescSSHKeys,
+				// cannot be updated in place, but reordering or moving keys from ssh_key is not a change
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplaceIf(
+					common.SSHKeysRequiresReplaceIf,
+					"Recreates the VM when the set of SSH keys changes.",
+					"Recreates the VM when the set of SSH keys changes.",
+				)},
+				Validators: []validator.List{validators.SSHKeysValidator{}},
 			},
 			"location": schema.StringAttribute{
 				Optional:            true,
@@ -198,6 +225,11 @@ func (r *vmResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 				MarkdownDescription: apiDescShutdownScript,
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()}, // cannot be updated in place
 			},
+			"reboot_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: providerDescRebootTriggers,
+			},
 			"disks": schema.SetNestedAttribute{
 				Optional:            true,
 				Computed:            true,
@@ -241,7 +273,14 @@ func (r *vmResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 				Computed:            true,
 				Optional:            true,
 				MarkdownDescription: apiDescNetworkInterfaces,
-				PlanModifiers:       []planmodifier.List{listplanmodifier.UseStateForUnknown()}, // maintain across updates
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(), // maintain across updates
+					listplanmodifier.RequiresReplaceIf(
+						networkInterfacesCountRequiresReplace,
+						"Recreates the VM when network interfaces are added or removed.",
+						"Recreates the VM when network interfaces are added or removed.",
+					),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					PlanModifiers: []planmodifier.Object{objectplanmodifier.UseStateForUnknown()}, // maintain across updates
 					Attributes: map[string]schema.Attribute{
@@ -298,10 +337,16 @@ func (r *vmResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 						},
 						"private_ipv4": schema.SingleNestedAttribute{
 							Computed: true,
+							Optional: true,
 							Attributes: map[string]schema.Attribute{
 								"address": schema.StringAttribute{
 									Computed:            true,
-									MarkdownDescription: apiDescPrivateIpv4Address,
+									Optional:            true,
+									MarkdownDescription: providerDescPrivateIpv4Address,
+									PlanModifiers: []planmodifier.String{
+										stringplanmodifier.UseStateForUnknown(),
+										stringplanmodifier.RequiresReplaceIfConfigured(),
+									}, // cannot be updated in place
 								},
 							},
 							PlanModifiers: []planmodifier.Object{objectplanmodifier.UseStateForUnknown()}, // maintain across updates
@@ -340,6 +385,10 @@ func (r *vmResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 				MarkdownDescription: apiDescInstallCrusoeWatchAgent,
 				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.RequiresReplace(), boolplanmodifier.UseStateForUnknown()},
 			},
+			"deletion_protection": common.DeletionProtectionAttribute("VM"),
+		},
+		Blocks: map[string]schema.Block{
+			"wait_for": waitForSchemaBlock(),
 		},
 	}
 }
@@ -388,14 +437,20 @@ func (r *vmResource) Create(ctx context.Context, req resource.CreateRequest, res
 		diags = plan.NetworkInterfaces.ElementsAs(ctx, &tNetworkInterfaces, true)
 		resp.Diagnostics.Append(diags...)
 
-		for _, networkInterface := range tNetworkInterfaces {
+		for i := range tNetworkInterfaces {
+			networkInterface := &tNetworkInterfaces[i]
+			ips := swagger.IpAddresses{
+				PublicIpv4: &swagger.PublicIpv4Address{
+					Type_: networkInterface.PublicIpv4.Type.ValueString(),
+				},
+			}
+			if address := configuredPrivateIPv4(networkInterface); address != "" {
+				ips.PrivateIpv4 = &swagger.PrivateIpv4Address{Address: address}
+			}
+
 			newNetworkInterfaces = append(newNetworkInterfaces, swagger.NetworkInterface{
 				Subnet: networkInterface.Subnet.ValueString(),
-				Ips: []swagger.IpAddresses{{
-					PublicIpv4: &swagger.PublicIpv4Address{
-						Type_: networkInterface.PublicIpv4.Type.ValueString(),
-					},
-				}},
+				Ips:    []swagger.IpAddresses{ips},
 			})
 		}
 	}
@@ -424,13 +479,21 @@ func (r *vmResource) Create(ctx context.Context, req resource.CreateRequest, res
 		installCrusoeWatchAgent = &v
 	}
 
+	sshPublicKey, err := common.JoinTFSSHKeys(plan.SSHKey, plan.SSHKeys)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create instance",
+			fmt.Sprintf("There was an error reading the configured SSH keys: %s", err))
+
+		return
+	}
+
 	dataResp, httpResp, err := r.client.APIClient.VMsApi.CreateInstance(ctx, swagger.InstancesPostRequestV1{
 		Name:                    plan.Name.ValueString(),
 		Type_:                   plan.Type.ValueString(),
 		Location:                plan.Location.ValueString(),
 		Image:                   plan.Image.ValueString(),
 		CustomImage:             plan.CustomImage.ValueString(),
-		SshPublicKey:            plan.SSHKey.ValueString(),
+		SshPublicKey:            sshPublicKey,
 		StartupScript:           plan.StartupScript.ValueString(),
 		ShutdownScript:          plan.ShutdownScript.ValueString(),
 		NetworkInterfaces:       newNetworkInterfaces,
@@ -478,6 +541,15 @@ func (r *vmResource) Create(ctx context.Context, req resource.CreateRequest, res
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// State is saved before waiting so that a VM which never becomes ready is tainted, not orphaned.
+	if err := awaitVMConditions(ctx, r.client.APIClient, projectID, plan.ID.ValueString(), plan.WaitFor); err != nil {
+		resp.Diagnostics.AddError("Instance did not become ready",
+			fmt.Sprintf("The instance was created, but the wait_for conditions were not met: %s", err))
+	}
 }
 
 //nolint:gocritic // Implements Terraform defined interface
@@ -500,6 +572,7 @@ func (r *vmResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 	}
 
 	vmToTerraformResourceModel(instance, &state)
+	state.DeletionProtection = common.DeletionProtectionOrDefault(state.DeletionProtection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -587,11 +660,13 @@ func (r *vmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		return
 	}
 
-	// handle updating public IP type, but only when the network interface configuration
+	// handle updating public IP types, but only when the network interface configuration
 	// actually changed. This avoids a redundant (and running-only) public IP update on
 	// every apply - e.g. a type-only resize, which would otherwise fail here if the VM
 	// is stopped (resizing leaves the VM stopped).
-	if !plan.NetworkInterfaces.IsUnknown() && len(plan.NetworkInterfaces.Elements()) == 1 &&
+	// Adding or removing interfaces, or changing a subnet or private address, replaces the VM
+	// instead (see networkInterfaceReplaceExplanation), so only public IP types can differ here.
+	if !plan.NetworkInterfaces.IsUnknown() && len(plan.NetworkInterfaces.Elements()) > 0 &&
 		!plan.NetworkInterfaces.Equal(state.NetworkInterfaces) {
 		// instances must be running to update public IP type
 		instance, httpResp, err := r.client.APIClient.VMsApi.GetInstance(ctx, state.ProjectID.ValueString(), state.ID.ValueString())
@@ -629,16 +704,21 @@ func (r *vmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		var tNetworkInterfaces []vmNetworkInterfaceResourceModel
 		diags = plan.NetworkInterfaces.ElementsAs(ctx, &tNetworkInterfaces, true)
 		resp.Diagnostics.Append(diags...)
-		patchResp, httpResp, err := r.client.APIClient.VMsApi.UpdateInstance(ctx, swagger.InstancesPatchRequestV1{
-			Action: "UPDATE",
-			NetworkInterfaces: []swagger.NetworkInterface{{
+		patchNetworkInterfaces := make([]swagger.NetworkInterface, 0, len(tNetworkInterfaces))
+		for _, networkInterface := range tNetworkInterfaces {
+			patchNetworkInterfaces = append(patchNetworkInterfaces, swagger.NetworkInterface{
+				Id: networkInterface.ID.ValueString(),
 				Ips: []swagger.IpAddresses{{
 					PublicIpv4: &swagger.PublicIpv4Address{
-						Id:    tNetworkInterfaces[0].PublicIpv4.ID.ValueString(),
-						Type_: tNetworkInterfaces[0].PublicIpv4.Type.ValueString(),
+						Id:    networkInterface.PublicIpv4.ID.ValueString(),
+						Type_: networkInterface.PublicIpv4.Type.ValueString(),
 					},
 				}},
-			}},
+			})
+		}
+		patchResp, httpResp, err := r.client.APIClient.VMsApi.UpdateInstance(ctx, swagger.InstancesPatchRequestV1{
+			Action:              "UPDATE",
+			NetworkInterfaces:   patchNetworkInterfaces,
 			HostChannelAdapters: hostChannelAdapters,
 		}, state.ProjectID.ValueString(), state.ID.ValueString())
 		if httpResp != nil {
@@ -666,6 +746,7 @@ func (r *vmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 
 	// resize the instance in place if the type changed within the same product family.
 	// (Cross-family changes trigger a replace via the schema plan modifier and never reach here.)
+	resized := false
 	if !plan.Type.IsUnknown() && !plan.Type.IsNull() && plan.Type.ValueString() != state.Type.ValueString() {
 		// fetch the current power state; the backend requires the VM to be stopped before a resize.
 		instance, httpResp, err := r.client.APIClient.VMsApi.GetInstance(ctx, state.ProjectID.ValueString(), state.ID.ValueString())
@@ -680,25 +761,11 @@ func (r *vmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		}
 
 		// stop the VM first if it isn't already stopped.
-		wasRunning := instance.State != StateStopped && instance.State != StateShutoff
+		wasRunning := !IsInstanceStopped(&instance)
 		if wasRunning {
-			stopResp, stopHTTPResp, stopErr := r.client.APIClient.VMsApi.UpdateInstance(ctx, swagger.InstancesPatchRequestV1{
-				Action: "STOP",
-			}, state.ProjectID.ValueString(), state.ID.ValueString())
-			if stopHTTPResp != nil {
-				defer stopHTTPResp.Body.Close()
-			}
-			if stopErr != nil {
+			if err := SetInstancePowerState(ctx, r.client.APIClient, state.ProjectID.ValueString(), state.ID.ValueString(), ActionStop); err != nil {
 				resp.Diagnostics.AddError("Failed to resize instance",
-					fmt.Sprintf("There was an error stopping the instance before resizing: %s", common.UnpackAPIError(stopErr)))
-
-				return
-			}
-
-			_, stopErr = common.AwaitOperation(ctx, stopResp.Operation, state.ProjectID.ValueString(), r.client.APIClient.VMOperationsApi.GetComputeVMsInstancesOperation)
-			if stopErr != nil {
-				resp.Diagnostics.AddError("Failed to resize instance",
-					fmt.Sprintf("There was an error stopping the instance before resizing: %s", common.UnpackAPIError(stopErr)))
+					fmt.Sprintf("There was an error stopping the instance before resizing: %s", err))
 
 				return
 			}
@@ -738,28 +805,44 @@ func (r *vmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		// restore the prior power state: resizing leaves the VM stopped, so start it
 		// again if it was running before the resize.
 		if wasRunning {
-			startResp, startHTTPResp, startErr := r.client.APIClient.VMsApi.UpdateInstance(ctx, swagger.InstancesPatchRequestV1{
-				Action: "START",
-			}, state.ProjectID.ValueString(), state.ID.ValueString())
-			if startHTTPResp != nil {
-				defer startHTTPResp.Body.Close()
-			}
-			if startErr != nil {
+			if err := SetInstancePowerState(ctx, r.client.APIClient, state.ProjectID.ValueString(), state.ID.ValueString(), ActionStart); err != nil {
 				resp.Diagnostics.AddError("Failed to start instance after resize",
-					fmt.Sprintf("The instance was resized but could not be restarted: %s", common.UnpackAPIError(startErr)))
+					fmt.Sprintf("The instance was resized but could not be restarted: %s", err))
 
 				return
 			}
+		}
+		resized = true
+	}
 
-			_, startErr = common.AwaitOperation(ctx, startResp.Operation, state.ProjectID.ValueString(), r.client.APIClient.VMOperationsApi.GetComputeVMsInstancesOperation)
-			if startErr != nil {
-				resp.Diagnostics.AddError("Failed to start instance after resize",
-					fmt.Sprintf("The instance was resized but could not be restarted: %s", common.UnpackAPIError(startErr)))
+	// reboot the instance if a reboot trigger changed.
+	if rebootRequired(state.RebootTriggers, plan.RebootTriggers, resized) {
+		instance, err := getVM(ctx, r.client.APIClient, state.ProjectID.ValueString(), state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to reboot instance",
+				fmt.Sprintf("There was an error fetching the instance's current state: %s", err))
+
+			return
+		}
+
+		// a stopped instance picks up the change the next time it is started.
+		if !IsInstanceStopped(instance) {
+			if err := SetInstancePowerState(ctx, r.client.APIClient, state.ProjectID.ValueString(), state.ID.ValueString(), ActionStop); err != nil {
+				resp.Diagnostics.AddError("Failed to reboot instance",
+					fmt.Sprintf("There was an error stopping the instance: %s", err))
+
+				return
+			}
+
+			if err := SetInstancePowerState(ctx, r.client.APIClient, state.ProjectID.ValueString(), state.ID.ValueString(), ActionStart); err != nil {
+				resp.Diagnostics.AddError("Failed to reboot instance",
+					fmt.Sprintf("The instance was stopped but could not be restarted: %s", err))
 
 				return
 			}
 		}
 	}
+	state.RebootTriggers = plan.RebootTriggers
 
 	//  Reservation ID is deprecated
 	if !plan.ReservationID.IsNull() && !plan.ReservationID.IsUnknown() && plan.ReservationID.ValueString() != "" {
@@ -770,11 +853,107 @@ func (r *vmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	debugMsg := "Setting state Reservation ID equal to plan Reservation ID, since the field is deprecated"
 	tflog.Debug(ctx, debugMsg, map[string]interface{}{})
 	state.ReservationID = plan.ReservationID
+
+	// SSH keys are not returned by the API; a change that reaches Update grants the same keys
+	// (see common.SSHKeysChanged), so only the configured representation needs saving.
+	state.SSHKey = plan.SSHKey
+	state.SSHKeys = plan.SSHKeys
+	state.WaitFor = plan.WaitFor // only checked on create
+	state.DeletionProtection = plan.DeletionProtection
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
+//nolint:gocritic // Implements Terraform defined interface
+func (r *vmResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.modifyPlanNetworkInterfaces(ctx, req, resp)
+	r.modifyPlanIBCapacity(ctx, req, resp)
+	common.ModifyPlanDeletionProtection(ctx, req, resp, "VM")
+}
+
+// modifyPlanIBCapacity checks that the VM's IB network has room for it when the VM is created or
+// moved to a different type or partition.
+//
+//nolint:gocritic // hugeParam: req is passed through from ModifyPlan
+func (r *vmResource) modifyPlanIBCapacity(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan vmResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	partitionID := hostChannelAdapterPartitionID(ctx, plan.HostChannelAdapters)
+	if partitionID == "" || plan.Type.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state vmResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if state.Type.Equal(plan.Type) && hostChannelAdapterPartitionID(ctx, state.HostChannelAdapters) == partitionID {
+			return
+		}
+	}
+
+	ib_network.CheckCapacity(ctx, r.client.APIClient, &ib_network.CapacityRequest{
+		ProjectID:    common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString()),
+		PartitionID:  partitionID,
+		InstanceType: plan.Type.ValueString(),
+		Count:        1,
+		Attribute:    path.Root("host_channel_adapters"),
+	}, &resp.Diagnostics)
+}
+
+// modifyPlanNetworkInterfaces checks requested private IPv4 addresses against their subnets and
+// explains network interface changes which replace the VM.
+//
+//nolint:gocritic // hugeParam: req is passed through from ModifyPlan
+func (r *vmResource) modifyPlanNetworkInterfaces(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan vmResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	priorNetworkInterfaces := types.ListNull(vmNetworkInterfaceSchema)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("network_interfaces"), &priorNetworkInterfaces)...)
+	}
+
+	projectID := common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString())
+	validateNetworkInterfaces(ctx, r.client.APIClient, projectID, plan.NetworkInterfaces, priorNetworkInterfaces, &resp.Diagnostics)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	for _, p := range resp.RequiresReplace {
+		if strings.HasPrefix(p.String(), "network_interfaces") {
+			resp.Diagnostics.AddAttributeWarning(path.Root("network_interfaces"),
+				"Network interface change requires replacement", networkInterfaceReplaceExplanation)
+
+			return
+		}
+	}
+}
+
 //nolint:gocritic // Implements Terraform defined interface
 func (r *vmResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if common.CheckDeletionProtection(ctx, req.State, "VM", &resp.Diagnostics) {
+		return
+	}
+
 	var state vmResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
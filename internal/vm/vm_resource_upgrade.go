@@ -69,6 +69,10 @@ var (
 
 		vmToTerraformResourceModel(instance, &state)
 		state.SSHKey = priorStateData.SSHKey
+		state.SSHKeys = types.ListNull(types.StringType) // prior versions only supported a single ssh_key
+		state.RebootTriggers = types.MapNull(types.StringType)
+		state.WaitFor = types.ObjectNull(vmWaitForSchema.AttrTypes)
+		state.DeletionProtection = types.BoolValue(false)
 		state.Image = priorStateData.Image
 		state.StartupScript = priorStateData.StartupScript
 		state.ShutdownScript = priorStateData.ShutdownScript
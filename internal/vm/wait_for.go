@@ -0,0 +1,223 @@
+package vm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	validators "github.com/crusoecloud/terraform-provider-crusoe/internal/validators"
+)
+
+const (
+	// WaitForAddressPublic and WaitForAddressPrivate select which IPv4 address a TCP probe targets.
+	WaitForAddressPublic  = "public"
+	WaitForAddressPrivate = "private"
+	waitForDefaultTimeout = "10m"
+
+	waitForPollInterval = 5 * time.Second
+	waitForDialTimeout  = 5 * time.Second
+)
+
+type vmWaitForResourceModel struct {
+	State       types.String `tfsdk:"state"`
+	Port        types.Int64  `tfsdk:"port"`
+	AddressType types.String `tfsdk:"address_type"`
+	Timeout     types.String `tfsdk:"timeout"`
+}
+
+var vmWaitForSchema = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"state":        types.StringType,
+		"port":         types.Int64Type,
+		"address_type": types.StringType,
+		"timeout":      types.StringType,
+	},
+}
+
+// waitForSchemaBlock is the wait_for block shared by the VM resources. The conditions are only
+// checked on create, so changing them never affects an existing VM.
+func waitForSchemaBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Conditions to wait for after the VM is created. Create only returns once every condition holds, " +
+			"and fails (leaving the VM tainted) if they do not hold within `timeout`. Changing these conditions does not affect an existing VM.",
+		Attributes: map[string]schema.Attribute{
+			"state": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf("State the VM must reach. Defaults to `%s`.", StateRunning),
+				Default:             stringdefault.StaticString(StateRunning),
+				Validators:          []validator.String{stringvalidator.OneOf(StateRunning, StateStopped, StateShutoff)},
+			},
+			"port": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "TCP port which must accept connections, for example `22` to wait for SSH.",
+				Validators:          []validator.Int64{int64validator.Between(1, 65535)},
+			},
+			"address_type": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Which IPv4 address of the first network interface to probe `port` on. " +
+					"Possible values: `public`, `private`. Defaults to `public`.",
+				Default:    stringdefault.StaticString(WaitForAddressPublic),
+				Validators: []validator.String{stringvalidator.OneOf(WaitForAddressPublic, WaitForAddressPrivate)},
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf("How long to wait for all conditions to hold, e.g. `30s` or `10m`. Defaults to `%s`.", waitForDefaultTimeout),
+				Default:             stringdefault.StaticString(waitForDefaultTimeout),
+				Validators:          []validator.String{validators.DurationValidator{}},
+			},
+		},
+	}
+}
+
+// awaitVMConditions blocks until the VM reaches the wait_for target state and, if a port is
+// configured, accepts TCP connections on it. It returns an error if the conditions do not hold
+// within the wait_for timeout. A null wait_for returns immediately.
+func awaitVMConditions(ctx context.Context, apiClient *swagger.APIClient, projectID, vmID string, waitForObj types.Object) error {
+	if waitForObj.IsNull() || waitForObj.IsUnknown() {
+		return nil
+	}
+
+	var waitFor vmWaitForResourceModel
+	if diags := waitForObj.As(ctx, &waitFor, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return errors.New("failed to read wait_for conditions")
+	}
+
+	timeout, err := time.ParseDuration(waitFor.Timeout.ValueString())
+	if err != nil {
+		return fmt.Errorf("invalid wait_for timeout: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	targetState := waitFor.State.ValueString()
+	instance, err := awaitVMState(ctx, apiClient, projectID, vmID, targetState)
+	if err != nil {
+		return waitForError(ctx, timeout, "the VM to reach "+targetState, err)
+	}
+
+	if waitFor.Port.IsNull() || waitFor.Port.IsUnknown() {
+		return nil
+	}
+
+	if err := awaitInstancePort(ctx, instance, waitFor.Port.ValueInt64(), waitFor.AddressType.ValueString()); err != nil {
+		return waitForError(ctx, timeout, fmt.Sprintf("port %d", waitFor.Port.ValueInt64()), err)
+	}
+
+	return nil
+}
+
+// AwaitVMPort blocks until the VM accepts TCP connections on port at the public or private IPv4
+// address of its first network interface, or ctx is done.
+func AwaitVMPort(ctx context.Context, apiClient *swagger.APIClient, projectID, vmID string, port int64, addressType string) error {
+	instance, err := getVM(ctx, apiClient, projectID, vmID)
+	if err != nil {
+		return err
+	}
+
+	return awaitInstancePort(ctx, instance, port, addressType)
+}
+
+// awaitInstancePort probes port on the instance's IPv4 address of the given type until it accepts
+// TCP connections or ctx is done.
+func awaitInstancePort(ctx context.Context, instance *swagger.InstanceV1, port int64, addressType string) error {
+	address := vmIPv4Address(instance, addressType)
+	if address == "" {
+		return fmt.Errorf("the VM has no %s IPv4 address to probe port %d on", addressType, port)
+	}
+
+	target := net.JoinHostPort(address, strconv.FormatInt(port, 10))
+	if err := awaitTCP(ctx, target); err != nil {
+		return fmt.Errorf("%s did not accept TCP connections: %w", target, err)
+	}
+
+	return nil
+}
+
+// waitForError wraps the error from a wait_for condition, only reporting a timeout when the
+// wait_for deadline has actually passed rather than e.g. the apply being interrupted.
+func waitForError(ctx context.Context, timeout time.Duration, condition string, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s waiting for %s: %w", timeout, condition, err)
+	}
+
+	return fmt.Errorf("failed waiting for %s: %w", condition, err)
+}
+
+// awaitVMState polls the VM until it reports targetState or ctx is done, returning the last error
+// or observed state on failure.
+func awaitVMState(ctx context.Context, apiClient *swagger.APIClient, projectID, vmID, targetState string) (*swagger.InstanceV1, error) {
+	for {
+		instance, err := getVM(ctx, apiClient, projectID, vmID)
+		if err == nil && instance.State == targetState {
+			return instance, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, fmt.Errorf("last observed state was %s", instance.State)
+		case <-time.After(waitForPollInterval):
+		}
+	}
+}
+
+// awaitTCP repeatedly dials target until a connection succeeds or ctx is done.
+func awaitTCP(ctx context.Context, target string) error {
+	dialer := net.Dialer{Timeout: waitForDialTimeout}
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", target)
+		if err == nil {
+			conn.Close()
+
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(waitForPollInterval):
+		}
+	}
+}
+
+// vmIPv4Address returns the public or private IPv4 address of the VM's first network interface,
+// or "" if it has none.
+func vmIPv4Address(instance *swagger.InstanceV1, addressType string) string {
+	if len(instance.NetworkInterfaces) == 0 || len(instance.NetworkInterfaces[0].Ips) == 0 {
+		return ""
+	}
+
+	ips := instance.NetworkInterfaces[0].Ips[0]
+	switch addressType {
+	case WaitForAddressPrivate:
+		if ips.PrivateIpv4 != nil {
+			return ips.PrivateIpv4.Address
+		}
+	default:
+		if ips.PublicIpv4 != nil {
+			return ips.PublicIpv4.Address
+		}
+	}
+
+	return ""
+}
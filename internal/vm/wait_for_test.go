@@ -0,0 +1,98 @@
+package vm
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+)
+
+func Test_vmIPv4Address(t *testing.T) {
+	instance := &swagger.InstanceV1{
+		NetworkInterfaces: []swagger.NetworkInterface{{
+			Ips: []swagger.IpAddresses{{
+				PublicIpv4:  &swagger.PublicIpv4Address{Address: "203.0.113.10"},
+				PrivateIpv4: &swagger.PrivateIpv4Address{Address: "10.0.0.5"},
+			}},
+		}},
+	}
+
+	tests := []struct {
+		name        string
+		instance    *swagger.InstanceV1
+		addressType string
+		want        string
+	}{
+		{name: "public", instance: instance, addressType: WaitForAddressPublic, want: "203.0.113.10"},
+		{name: "private", instance: instance, addressType: WaitForAddressPrivate, want: "10.0.0.5"},
+		{name: "no interfaces", instance: &swagger.InstanceV1{}, addressType: WaitForAddressPublic, want: ""},
+		{
+			name: "no public address",
+			instance: &swagger.InstanceV1{NetworkInterfaces: []swagger.NetworkInterface{{
+				Ips: []swagger.IpAddresses{{PrivateIpv4: &swagger.PrivateIpv4Address{Address: "10.0.0.5"}}},
+			}}},
+			addressType: WaitForAddressPublic,
+			want:        "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := vmIPv4Address(tt.instance, tt.addressType); got != tt.want {
+				t.Errorf("vmIPv4Address() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_awaitTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	addr := listener.Addr().String()
+
+	t.Run("open port", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		if err := awaitTCP(ctx, addr); err != nil {
+			t.Errorf("awaitTCP() error = %v, want nil", err)
+		}
+	})
+
+	listener.Close()
+
+	t.Run("closed port times out", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		if err := awaitTCP(ctx, addr); err == nil {
+			t.Error("awaitTCP() error = nil, want an error for a closed port")
+		}
+	})
+}
+
+func Test_waitForError(t *testing.T) {
+	cause := errors.New("connection refused")
+
+	expired, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-expired.Done()
+	if got := waitForError(expired, time.Minute, "the VM", cause).Error(); got != "timed out after 1m0s waiting for the VM: connection refused" {
+		t.Errorf("deadline exceeded: got %q", got)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := waitForError(canceled, time.Minute, "the VM", cause).Error(); got != "failed waiting for the VM: connection refused" {
+		t.Errorf("canceled: got %q", got)
+	}
+
+	if err := waitForError(canceled, time.Minute, "the VM", cause); !errors.Is(err, cause) {
+		t.Errorf("error does not wrap the cause: %v", err)
+	}
+}
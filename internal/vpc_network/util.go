@@ -22,7 +22,9 @@ const (
 
 // providerDesc* — provider-specific schema descriptions (Terraform-side; not from the spec).
 const (
-	providerDescProjectID = "ID of the project the VPC network belongs to. " + project.ProviderDescProjectIDFallback
+	providerDescProjectID  = "ID of the project the VPC network belongs to. " + project.ProviderDescProjectIDFallback
+	providerDescLookupID   = "At least one of `id` and `name` must be set to look the network up."
+	providerDescLookupName = "Exactly one network in the project must have this name."
 )
 
 func findVpcNetwork(ctx context.Context, client *swagger.APIClient, vpcNetworkID string) (*swagger.VpcNetwork, string, error) {
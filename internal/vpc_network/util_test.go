@@ -51,3 +51,29 @@ func Test_vpcNetworkToTerraformResourceModel(t *testing.T) {
 		t.Errorf("subnets = %v, want %v (sorted)", gotSubnets, wantSubnets)
 	}
 }
+
+func Test_selectVPCNetwork(t *testing.T) {
+	networks := []swagger.VpcNetwork{
+		{Id: "vpc-1", Name: "prod-vpc"},
+		{Id: "vpc-2", Name: "dev-vpc"},
+		{Id: "vpc-3", Name: "dev-vpc"},
+	}
+
+	got, err := selectVPCNetwork(networks, &vpcNetworkDataSourceModel{ID: types.StringNull(), Name: types.StringValue("prod-vpc")})
+	if err != nil || got.Id != "vpc-1" {
+		t.Errorf("selectVPCNetwork(prod-vpc) = %v, %v, want vpc-1", got, err)
+	}
+
+	got, err = selectVPCNetwork(networks, &vpcNetworkDataSourceModel{ID: types.StringValue("vpc-3"), Name: types.StringNull()})
+	if err != nil || got.Id != "vpc-3" {
+		t.Errorf("selectVPCNetwork(vpc-3) = %v, %v, want vpc-3", got, err)
+	}
+
+	if _, err := selectVPCNetwork(networks, &vpcNetworkDataSourceModel{ID: types.StringNull(), Name: types.StringValue("dev-vpc")}); err == nil {
+		t.Error("selectVPCNetwork(dev-vpc) returned no error for two matching networks")
+	}
+
+	if _, err := selectVPCNetwork(networks, &vpcNetworkDataSourceModel{ID: types.StringNull(), Name: types.StringValue("missing")}); err == nil {
+		t.Error("selectVPCNetwork(missing) returned no error for zero matching networks")
+	}
+}
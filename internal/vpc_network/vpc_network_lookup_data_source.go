@@ -0,0 +1,154 @@
+package vpc_network
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+// vpcNetworkDataSource looks up a single VPC network by ID or name.
+type vpcNetworkDataSource struct {
+	client *common.CrusoeClient
+}
+
+type vpcNetworkDataSourceModel struct {
+	ProjectID types.String `tfsdk:"project_id"`
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	CIDR      types.String `tfsdk:"cidr"`
+	Gateway   types.String `tfsdk:"gateway"`
+	Subnets   types.List   `tfsdk:"subnets"`
+}
+
+func NewVPCNetworkDataSource() datasource.DataSource {
+	return &vpcNetworkDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (ds *vpcNetworkDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*common.CrusoeClient)
+	if !ok {
+		resp.Diagnostics.AddError("Failed to initialize provider", common.ErrorMsgProviderInitFailed)
+
+		return
+	}
+
+	ds.client = client
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (ds *vpcNetworkDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_vpc_network"
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (ds *vpcNetworkDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{Attributes: map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: apiDescID + " " + providerDescLookupID,
+			Validators:  []validator.String{stringvalidator.AtLeastOneOf(path.MatchRoot("name"))},
+		},
+		"name": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: apiDescName + " " + providerDescLookupName,
+		},
+		"cidr": schema.StringAttribute{
+			Computed:    true,
+			Description: apiDescCIDR,
+		},
+		"gateway": schema.StringAttribute{
+			Computed:    true,
+			Description: apiDescGateway,
+		},
+		"subnets": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: apiDescSubnets,
+		},
+		"project_id": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: providerDescProjectID,
+		},
+	}}
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (ds *vpcNetworkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config vpcNetworkDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := common.GetProjectIDOrFallback(ds.client, config.ProjectID.ValueString())
+
+	dataResp, httpResp, err := ds.client.APIClient.VPCNetworksApi.ListVPCNetworks(ctx, projectID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Fetch VPC Networks",
+			fmt.Sprintf("Could not fetch VPC Network data at this time: %s", common.UnpackAPIError(err)))
+
+		return
+	}
+
+	vpcNetwork, err := selectVPCNetwork(dataResp.Items, &config)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to find VPC Network", fmt.Sprintf("%s in project %s.", err, projectID))
+
+		return
+	}
+
+	// Sort subnet IDs for deterministic ordering; the API does not guarantee a stable order.
+	subnets := slices.Clone(vpcNetwork.Subnets)
+	slices.Sort(subnets)
+
+	state := vpcNetworkDataSourceModel{
+		ProjectID: types.StringValue(projectID),
+		ID:        types.StringValue(vpcNetwork.Id),
+		Name:      types.StringValue(vpcNetwork.Name),
+		CIDR:      types.StringValue(vpcNetwork.Cidr),
+		Gateway:   types.StringValue(vpcNetwork.Gateway),
+	}
+	subnetList, diags := types.ListValueFrom(ctx, types.StringType, subnets)
+	resp.Diagnostics.Append(diags...)
+	state.Subnets = subnetList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// selectVPCNetwork returns the one network matching the configured ID and name.
+func selectVPCNetwork(networks []swagger.VpcNetwork, config *vpcNetworkDataSourceModel) (*swagger.VpcNetwork, error) {
+	var criteria []string
+	if !config.ID.IsNull() {
+		criteria = append(criteria, fmt.Sprintf("id %q", config.ID.ValueString()))
+	}
+	if !config.Name.IsNull() {
+		criteria = append(criteria, fmt.Sprintf("name %q", config.Name.ValueString()))
+	}
+
+	return common.SelectSingle(networks, func(n *swagger.VpcNetwork) bool {
+		return (config.ID.IsNull() || n.Id == config.ID.ValueString()) &&
+			(config.Name.IsNull() || n.Name == config.Name.ValueString())
+	}, "VPC network", strings.Join(criteria, " and "))
+}
@@ -0,0 +1,109 @@
+package vpc_subnet
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sync"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+// cidrAllocationMu serializes allocating a CIDR block and creating the subnet with it, so
+// subnets created in the same apply aren't given the same block.
+var cidrAllocationMu sync.Mutex
+
+// siblingSubnet is an existing subnet in the same VPC network.
+type siblingSubnet struct {
+	ID     string
+	Name   string
+	Prefix netip.Prefix
+}
+
+// networkAddressSpace returns the VPC network's CIDR block and the other subnets in it. The subnet
+// with excludeID, if any, is left out so a subnet isn't compared with itself.
+func networkAddressSpace(ctx context.Context, apiClient *swagger.APIClient, projectID, networkID, excludeID string,
+) (network netip.Prefix, siblings []siblingSubnet, err error) {
+	vpcNetwork, httpResp, err := apiClient.VPCNetworksApi.GetVPCNetwork(ctx, projectID, networkID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return netip.Prefix{}, nil, fmt.Errorf("failed to get VPC network %s: %w", networkID, common.UnpackAPIError(err))
+	}
+
+	network, err = netip.ParsePrefix(vpcNetwork.Cidr)
+	if err != nil {
+		return netip.Prefix{}, nil, fmt.Errorf("VPC network %s has an invalid CIDR %q: %w", networkID, vpcNetwork.Cidr, err)
+	}
+
+	subnets, subnetsResp, err := apiClient.VPCSubnetsApi.ListVPCSubnets(ctx, projectID)
+	if subnetsResp != nil {
+		defer subnetsResp.Body.Close()
+	}
+	if err != nil {
+		return netip.Prefix{}, nil, fmt.Errorf("failed to list VPC subnets: %w", common.UnpackAPIError(err))
+	}
+
+	for i := range subnets.Items {
+		subnet := &subnets.Items[i]
+		if subnet.VpcNetworkId != networkID || subnet.Id == excludeID {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(subnet.Cidr)
+		if err != nil {
+			continue
+		}
+		siblings = append(siblings, siblingSubnet{ID: subnet.Id, Name: subnet.Name, Prefix: prefix.Masked()})
+	}
+
+	return network.Masked(), siblings, nil
+}
+
+// checkSubnetCIDR reports an error if cidr isn't contained in the network or overlaps one of the
+// sibling subnets.
+func checkSubnetCIDR(cidr, network netip.Prefix, siblings []siblingSubnet) error {
+	if cidr.Addr().Is4() != network.Addr().Is4() || cidr.Bits() < network.Bits() || !network.Contains(cidr.Addr()) {
+		return fmt.Errorf("%s is not within the VPC network's CIDR %s", cidr, network)
+	}
+
+	for _, sibling := range siblings {
+		if sibling.Prefix.Overlaps(cidr) {
+			return fmt.Errorf("%s overlaps subnet %s (%s), which uses %s", cidr, sibling.Name, sibling.ID, sibling.Prefix)
+		}
+	}
+
+	return nil
+}
+
+// allocateSubnetCIDR returns the lowest block of the given prefix length inside the network which
+// doesn't overlap any of the sibling subnets.
+func allocateSubnetCIDR(network netip.Prefix, siblings []siblingSubnet, prefixLength int) (netip.Prefix, error) {
+	if !network.Addr().Is4() {
+		return netip.Prefix{}, errors.New("automatic CIDR allocation only supports IPv4 networks")
+	}
+	if prefixLength < network.Bits() || prefixLength > 32 {
+		return netip.Prefix{}, fmt.Errorf("a /%d block does not fit in the VPC network's CIDR %s", prefixLength, network)
+	}
+
+	start := network.Addr().As4()
+	first := binary.BigEndian.Uint32(start[:])
+	blockSize := uint64(1) << (32 - prefixLength)
+	networkSize := uint64(1) << (32 - network.Bits())
+
+	for offset := uint64(0); offset < networkSize; offset += blockSize {
+		var addr [4]byte
+		binary.BigEndian.PutUint32(addr[:], first+uint32(offset))
+		candidate := netip.PrefixFrom(netip.AddrFrom4(addr), prefixLength)
+
+		if checkSubnetCIDR(candidate, network, siblings) == nil {
+			return candidate, nil
+		}
+	}
+
+	return netip.Prefix{}, fmt.Errorf("no free /%d block is left in the VPC network's CIDR %s", prefixLength, network)
+}
@@ -0,0 +1,71 @@
+package vpc_subnet
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func testSiblings(cidrs ...string) []siblingSubnet {
+	siblings := make([]siblingSubnet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		siblings = append(siblings, siblingSubnet{ID: "subnet-" + cidr, Name: cidr, Prefix: netip.MustParsePrefix(cidr)})
+	}
+
+	return siblings
+}
+
+func Test_checkSubnetCIDR(t *testing.T) {
+	network := netip.MustParsePrefix("10.0.0.0/16")
+	siblings := testSiblings("10.0.0.0/24", "10.0.4.0/22")
+
+	tests := []struct {
+		name    string
+		cidr    string
+		wantErr bool
+	}{
+		{name: "free block", cidr: "10.0.1.0/24"},
+		{name: "outside the network", cidr: "10.1.0.0/24", wantErr: true},
+		{name: "larger than the network", cidr: "10.0.0.0/8", wantErr: true},
+		{name: "overlaps a sibling", cidr: "10.0.0.128/25", wantErr: true},
+		{name: "contains a sibling", cidr: "10.0.0.0/20", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSubnetCIDR(netip.MustParsePrefix(tt.cidr), network, siblings)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkSubnetCIDR(%s) error = %v, wantErr %v", tt.cidr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_allocateSubnetCIDR(t *testing.T) {
+	network := netip.MustParsePrefix("10.0.0.0/16")
+
+	tests := []struct {
+		name         string
+		siblings     []siblingSubnet
+		prefixLength int
+		want         string
+		wantErr      bool
+	}{
+		{name: "empty network", prefixLength: 24, want: "10.0.0.0/24"},
+		{name: "skips used blocks", siblings: testSiblings("10.0.0.0/24", "10.0.1.0/24"), prefixLength: 24, want: "10.0.2.0/24"},
+		{name: "fills a gap", siblings: testSiblings("10.0.0.0/24", "10.0.2.0/24"), prefixLength: 24, want: "10.0.1.0/24"},
+		{name: "aligns to the block size", siblings: testSiblings("10.0.0.0/24"), prefixLength: 22, want: "10.0.4.0/22"},
+		{name: "whole network", prefixLength: 16, want: "10.0.0.0/16"},
+		{name: "network full", siblings: testSiblings("10.0.0.0/17", "10.0.128.0/17"), prefixLength: 24, wantErr: true},
+		{name: "larger than the network", prefixLength: 8, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := allocateSubnetCIDR(network, tt.siblings, tt.prefixLength)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("allocateSubnetCIDR() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("allocateSubnetCIDR() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package vpc_subnet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+var (
+	_ resource.Resource                = &natGatewayResource{}
+	_ resource.ResourceWithImportState = &natGatewayResource{}
+)
+
+// natGatewayResource manages a subnet's NAT gateway separately from the subnet. The API has no
+// NAT gateway endpoints of its own, so the gateway is enabled and disabled through the subnet.
+type natGatewayResource struct {
+	client *common.CrusoeClient
+}
+
+type natGatewayResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ProjectID         types.String `tfsdk:"project_id"`
+	Subnet            types.String `tfsdk:"subnet"`
+	PublicIpv4Address types.String `tfsdk:"public_ipv4_address"`
+	PublicIpv4Id      types.String `tfsdk:"public_ipv4_id"`
+}
+
+func NewVPCNATGatewayResource() resource.Resource {
+	return &natGatewayResource{}
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (r *natGatewayResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*common.CrusoeClient)
+	if !ok {
+		resp.Diagnostics.AddError("Failed to initialize provider", common.ErrorMsgProviderInitFailed)
+
+		return
+	}
+
+	r.client = client
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (r *natGatewayResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vpc_nat_gateway"
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (r *natGatewayResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: providerDescNATGatewayResource + " " + common.DevelopmentMessage,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   apiDescNATGatewayID,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, // maintain across updates
+			},
+			"project_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: providerDescNATGatewayProjectID,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subnet": schema.StringAttribute{
+				Required:      true,
+				Description:   providerDescNATGatewaySubnet,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}, // cannot be updated in place
+			},
+			"public_ipv4_address": schema.StringAttribute{
+				Computed:      true,
+				Description:   apiDescNATGatewayPublicIPv4Address,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, // maintain across updates
+			},
+			"public_ipv4_id": schema.StringAttribute{
+				Computed:      true,
+				Description:   apiDescNATGatewayPublicIPv4ID,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}, // maintain across updates
+			},
+		},
+	}
+}
+
+// ImportState imports the NAT gateway of a subnet, identified by the subnet's ID.
+func (r *natGatewayResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	subnetID, projectID, errMsg := common.ParseResourceIdentifiers(req, r.client, "vpc_subnet_id")
+	if errMsg != "" {
+		resp.Diagnostics.AddError("Failed to import NAT Gateway", errMsg)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subnet"), subnetID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (r *natGatewayResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan natGatewayResourceModel
+	if err := common.GetResourceModel(ctx, req.Plan, &plan, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	projectID := common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString())
+
+	vpcSubnet, httpResp, err := r.client.APIClient.VPCSubnetsApi.GetVPCSubnet(ctx, projectID, plan.Subnet.ValueString())
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create NAT Gateway",
+			fmt.Sprintf("There was an error fetching the VPC Subnet: %s", common.UnpackAPIError(err)))
+
+		return
+	}
+
+	if len(vpcSubnet.NatGateways) > 0 {
+		resp.Diagnostics.AddError("Failed to create NAT Gateway",
+			fmt.Sprintf("VPC Subnet %s already has a NAT gateway. Import it with its subnet ID to manage it from Terraform.", vpcSubnet.Id))
+
+		return
+	}
+
+	updated, err := r.setNATGateway(ctx, projectID, &vpcSubnet, "enable")
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create NAT Gateway",
+			fmt.Sprintf("There was an error enabling the NAT gateway on the VPC Subnet: %s", common.UnpackAPIError(err)))
+
+		return
+	}
+
+	if len(updated.NatGateways) == 0 {
+		resp.Diagnostics.AddError("Failed to create NAT Gateway",
+			"The NAT gateway was enabled, but the VPC Subnet does not report one.")
+
+		return
+	}
+
+	plan.ProjectID = types.StringValue(projectID)
+	natGatewayToTerraformResourceModel(&updated.NatGateways[0], &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (r *natGatewayResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state natGatewayResourceModel
+	if err := common.GetResourceModel(ctx, req.State, &state, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	projectID := common.GetProjectIDOrFallback(r.client, state.ProjectID.ValueString())
+
+	vpcSubnet, httpResp, err := r.client.APIClient.VPCSubnetsApi.GetVPCSubnet(ctx, projectID, state.Subnet.ValueString())
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		// The subnet, and with it the NAT gateway, has most likely been deleted out of band
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get NAT Gateway",
+			fmt.Sprintf("Fetching the VPC Subnet failed: %s\n\nIf the problem persists, contact support@crusoecloud.com", common.UnpackAPIError(err)))
+
+		return
+	}
+
+	gateway := findNATGateway(vpcSubnet.NatGateways, state.ID.ValueString())
+	if gateway == nil {
+		// NAT gateway has most likely been disabled out of band, so we update Terraform state to match
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	state.ProjectID = types.StringValue(projectID)
+	natGatewayToTerraformResourceModel(gateway, &state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only stores the plan: every configurable attribute requires replacement.
+//
+//nolint:gocritic // Implements Terraform defined interface
+func (r *natGatewayResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan natGatewayResourceModel
+	if err := common.GetResourceModel(ctx, req.Plan, &plan, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (r *natGatewayResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state natGatewayResourceModel
+	if err := common.GetResourceModel(ctx, req.State, &state, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	projectID := common.GetProjectIDOrFallback(r.client, state.ProjectID.ValueString())
+
+	vpcSubnet, httpResp, err := r.client.APIClient.VPCSubnetsApi.GetVPCSubnet(ctx, projectID, state.Subnet.ValueString())
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to delete NAT Gateway",
+			fmt.Sprintf("There was an error fetching the VPC Subnet: %s", common.UnpackAPIError(err)))
+
+		return
+	}
+
+	if findNATGateway(vpcSubnet.NatGateways, state.ID.ValueString()) == nil {
+		return
+	}
+
+	if _, err := r.setNATGateway(ctx, projectID, &vpcSubnet, "disable"); err != nil {
+		resp.Diagnostics.AddError("Failed to delete NAT Gateway",
+			fmt.Sprintf("There was an error disabling the NAT gateway on the VPC Subnet: %s", common.UnpackAPIError(err)))
+
+		return
+	}
+}
+
+// setNATGateway enables or disables the subnet's NAT gateway and returns the updated subnet.
+func (r *natGatewayResource) setNATGateway(ctx context.Context, projectID string, vpcSubnet *swagger.VpcSubnet, action string,
+) (*swagger.VpcSubnet, error) {
+	dataResp, httpResp, err := r.client.APIClient.VPCSubnetsApi.PatchVPCSubnet(ctx, swagger.VpcSubnetPatchRequest{
+		Name:             vpcSubnet.Name,
+		NatGatewayAction: action,
+	}, projectID, vpcSubnet.Id)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	updated, _, err := common.AwaitOperationAndResolve[swagger.VpcSubnet](ctx, dataResp.Operation, projectID, func(ctx context.Context, projectID string, opID string) (swagger.Operation, *http.Response, error) {
+		return r.client.APIClient.VPCSubnetOperationsApi.GetNetworkingVPCSubnetsOperation(ctx, projectID, opID)
+	})
+
+	return updated, err
+}
+
+// findNATGateway returns the gateway with the given ID, or the subnet's first gateway when id is
+// empty, as it is right after an import.
+func findNATGateway(gateways []swagger.NatGateway, id string) *swagger.NatGateway {
+	for i := range gateways {
+		if id == "" || gateways[i].Id == id {
+			return &gateways[i]
+		}
+	}
+
+	return nil
+}
+
+func natGatewayToTerraformResourceModel(gateway *swagger.NatGateway, state *natGatewayResourceModel) {
+	state.ID = types.StringValue(gateway.Id)
+	state.PublicIpv4Address = types.StringValue(gateway.PublicIpv4Address)
+	state.PublicIpv4Id = types.StringValue(gateway.PublicIpv4Id)
+}
@@ -31,6 +31,20 @@ const (
 // providerDesc* — provider-specific schema descriptions (Terraform-side; not from the spec).
 const (
 	providerDescProjectID = "ID of the project the VPC subnet belongs to. " + project.ProviderDescProjectIDFallback
+	providerDescCIDR      = "Exactly one of `cidr` and `cidr_prefix_length` must be set. An explicit CIDR is checked at plan time" +
+		" to be inside the VPC network and not to overlap other subnets in it."
+	providerDescCIDRPrefixLength = "Prefix length of a CIDR block to allocate automatically, for example `24`. The lowest free" +
+		" block of this size in the VPC network's CIDR is used, and the result is available in `cidr`."
+	providerDescManageNATGateway = "Whether this resource manages the subnet's NAT gateway through `nat_gateway_enabled`." +
+		" Set to `false` to manage it with a `crusoe_vpc_nat_gateway` resource instead, in which case `nat_gateway_enabled` is ignored."
+	providerDescNATGatewayResource = "NAT gateway of a VPC subnet, managed independently of the subnet." +
+		" The subnet's `manage_nat_gateway` should be set to `false`. Import with the subnet's ID." +
+		" The gateway's public IP is assigned by Crusoe Cloud; choosing a reserved public IP is not supported, because the API has no way to pass one."
+	providerDescNATGatewayProjectID = "ID of the project the VPC subnet belongs to. " + project.ProviderDescProjectIDFallback
+	providerDescNATGatewaySubnet    = "ID of the VPC subnet to create the NAT gateway for."
+	providerDescLookupID            = "At least one of `id` and `name` must be set to look the subnet up."
+	providerDescLookupName          = "Exactly one subnet in the project, or in `location` if set, must have this name."
+	providerDescLookupLocation      = "Only consider subnets in this location when looking up by name."
 )
 
 var vpcSubnetNatGatewaySchema = types.ObjectType{
@@ -61,7 +75,14 @@ func vpcSubnetToTerraformResourceModel(ctx context.Context, vpcSubnet *swagger.V
 	state.Network = types.StringValue(vpcSubnet.VpcNetworkId)
 	natGatewaysList, natDiags := natGatewaysToTerraformResourceModel(ctx, vpcSubnet.NatGateways)
 	state.NATGateways = natGatewaysList
-	state.NATGatewayEnabled = types.BoolValue(len(natGatewaysList.Elements()) > 0)
+	if state.ManageNATGateway.IsNull() || state.ManageNATGateway.IsUnknown() {
+		state.ManageNATGateway = types.BoolValue(true)
+	}
+	// A NAT gateway managed elsewhere, such as by crusoe_vpc_nat_gateway, isn't reflected in
+	// nat_gateway_enabled, so it doesn't show up as drift on the subnet.
+	if state.ManageNATGateway.ValueBool() || state.NATGatewayEnabled.IsNull() || state.NATGatewayEnabled.IsUnknown() {
+		state.NATGatewayEnabled = types.BoolValue(len(natGatewaysList.Elements()) > 0)
+	}
 	diags.Append(natDiags...)
 }
 
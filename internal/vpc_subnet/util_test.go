@@ -82,3 +82,77 @@ func Test_vpcSubnetToTerraformResourceModel_noGateways(t *testing.T) {
 		t.Error("nat_gateway_enabled = true, want false (no gateways)")
 	}
 }
+
+// Test_vpcSubnetToTerraformResourceModel_unmanagedNAT confirms a NAT gateway managed
+// outside the subnet resource doesn't change nat_gateway_enabled.
+func Test_vpcSubnetToTerraformResourceModel_unmanagedNAT(t *testing.T) {
+	ctx := context.Background()
+	state := &vpcSubnetResourceModel{
+		NATGatewayEnabled: types.BoolValue(false),
+		ManageNATGateway:  types.BoolValue(false),
+	}
+	subnet := &swagger.VpcSubnet{Id: "subnet-1", NatGateways: []swagger.NatGateway{{Id: "nat-a"}}}
+
+	var diags diag.Diagnostics
+	vpcSubnetToTerraformResourceModel(ctx, subnet, state, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if state.NATGatewayEnabled.ValueBool() {
+		t.Error("nat_gateway_enabled = true, want the configured false while NAT is managed elsewhere")
+	}
+	if len(state.NATGateways.Elements()) != 1 {
+		t.Errorf("nat_gateways has %d elements, want 1", len(state.NATGateways.Elements()))
+	}
+}
+
+func Test_findNATGateway(t *testing.T) {
+	gateways := []swagger.NatGateway{{Id: "nat-a"}, {Id: "nat-b"}}
+
+	if got := findNATGateway(gateways, "nat-b"); got == nil || got.Id != "nat-b" {
+		t.Errorf("findNATGateway(nat-b) = %v, want nat-b", got)
+	}
+	if got := findNATGateway(gateways, ""); got == nil || got.Id != "nat-a" {
+		t.Errorf("findNATGateway(\"\") = %v, want the first gateway", got)
+	}
+	if got := findNATGateway(gateways, "nat-c"); got != nil {
+		t.Errorf("findNATGateway(nat-c) = %v, want nil", got)
+	}
+}
+
+func Test_selectVPCSubnet(t *testing.T) {
+	subnets := []swagger.VpcSubnet{
+		{Id: "subnet-1", Name: "gpu-subnet", Location: "us-east1-a"},
+		{Id: "subnet-2", Name: "gpu-subnet", Location: "eu-iceland1-a"},
+		{Id: "subnet-3", Name: "cpu-subnet", Location: "us-east1-a"},
+	}
+
+	tests := []struct {
+		name    string
+		config  vpcSubnetDataSourceModel
+		wantID  string
+		wantErr bool
+	}{
+		{name: "by id", config: vpcSubnetDataSourceModel{ID: types.StringValue("subnet-3")}, wantID: "subnet-3"},
+		{name: "by unique name", config: vpcSubnetDataSourceModel{Name: types.StringValue("cpu-subnet")}, wantID: "subnet-3"},
+		{
+			name:   "by name and location",
+			config: vpcSubnetDataSourceModel{Name: types.StringValue("gpu-subnet"), Location: types.StringValue("eu-iceland1-a")},
+			wantID: "subnet-2",
+		},
+		{name: "ambiguous name", config: vpcSubnetDataSourceModel{Name: types.StringValue("gpu-subnet")}, wantErr: true},
+		{name: "no match", config: vpcSubnetDataSourceModel{Name: types.StringValue("missing")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectVPCSubnet(subnets, &tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectVPCSubnet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Id != tt.wantID {
+				t.Errorf("selectVPCSubnet() = %s, want %s", got.Id, tt.wantID)
+			}
+		})
+	}
+}
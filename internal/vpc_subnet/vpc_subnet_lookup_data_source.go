@@ -0,0 +1,186 @@
+package vpc_subnet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	swagger "github.com/crusoecloud/client-go/swagger/v1"
+	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
+)
+
+// vpcSubnetDataSource looks up a single VPC subnet by ID or name, optionally narrowed by location.
+type vpcSubnetDataSource struct {
+	client *common.CrusoeClient
+}
+
+type vpcSubnetDataSourceModel struct {
+	ProjectID         types.String `tfsdk:"project_id"`
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Location          types.String `tfsdk:"location"`
+	CIDR              types.String `tfsdk:"cidr"`
+	Network           types.String `tfsdk:"network"`
+	NATGatewayEnabled types.Bool   `tfsdk:"nat_gateway_enabled"`
+	NATGateways       types.List   `tfsdk:"nat_gateways"`
+}
+
+func NewVPCSubnetDataSource() datasource.DataSource {
+	return &vpcSubnetDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (ds *vpcSubnetDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*common.CrusoeClient)
+	if !ok {
+		resp.Diagnostics.AddError("Failed to initialize provider", common.ErrorMsgProviderInitFailed)
+
+		return
+	}
+
+	ds.client = client
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (ds *vpcSubnetDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_vpc_subnet"
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (ds *vpcSubnetDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{Attributes: map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: apiDescID + " " + providerDescLookupID,
+			Validators:  []validator.String{stringvalidator.AtLeastOneOf(path.MatchRoot("name"))},
+		},
+		"name": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: apiDescName + " " + providerDescLookupName,
+		},
+		"location": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: apiDescLocation + " " + providerDescLookupLocation,
+		},
+		"cidr": schema.StringAttribute{
+			Computed:    true,
+			Description: apiDescCIDR,
+		},
+		"network": schema.StringAttribute{
+			Computed:    true,
+			Description: apiDescNetwork,
+		},
+		"nat_gateway_enabled": schema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: apiDescNATGatewayEnabled + " " + common.DevelopmentMessage,
+		},
+		"nat_gateways": schema.ListNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: apiDescNATGateways + " " + common.DevelopmentMessage,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed:    true,
+						Description: apiDescNATGatewayID,
+					},
+					"public_ipv4_address": schema.StringAttribute{
+						Computed:    true,
+						Description: apiDescNATGatewayPublicIPv4Address,
+					},
+					"public_ipv4_id": schema.StringAttribute{
+						Computed:    true,
+						Description: apiDescNATGatewayPublicIPv4ID,
+					},
+				},
+			},
+		},
+		"project_id": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: providerDescProjectID,
+		},
+	}}
+}
+
+//nolint:gocritic // Implements Terraform defined interface
+func (ds *vpcSubnetDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config vpcSubnetDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := common.GetProjectIDOrFallback(ds.client, config.ProjectID.ValueString())
+
+	dataResp, httpResp, err := ds.client.APIClient.VPCSubnetsApi.ListVPCSubnets(ctx, projectID)
+	if httpResp != nil {
+		defer httpResp.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Fetch VPC Subnets",
+			fmt.Sprintf("Could not fetch VPC Subnet data at this time: %s", common.UnpackAPIError(err)))
+
+		return
+	}
+
+	vpcSubnet, err := selectVPCSubnet(dataResp.Items, &config)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to find VPC Subnet", fmt.Sprintf("%s in project %s.", err, projectID))
+
+		return
+	}
+
+	// Share the resource's transform so the data source exposes the same fields.
+	var subnet vpcSubnetResourceModel
+	vpcSubnetToTerraformResourceModel(ctx, vpcSubnet, &subnet, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := vpcSubnetDataSourceModel{
+		ProjectID:         types.StringValue(projectID),
+		ID:                subnet.ID,
+		Name:              subnet.Name,
+		Location:          subnet.Location,
+		CIDR:              subnet.CIDR,
+		Network:           subnet.Network,
+		NATGatewayEnabled: subnet.NATGatewayEnabled,
+		NATGateways:       subnet.NATGateways,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// selectVPCSubnet returns the one subnet matching the configured ID, name and location.
+func selectVPCSubnet(subnets []swagger.VpcSubnet, config *vpcSubnetDataSourceModel) (*swagger.VpcSubnet, error) {
+	var criteria []string
+	if !config.ID.IsNull() {
+		criteria = append(criteria, fmt.Sprintf("id %q", config.ID.ValueString()))
+	}
+	if !config.Name.IsNull() {
+		criteria = append(criteria, fmt.Sprintf("name %q", config.Name.ValueString()))
+	}
+	if !config.Location.IsNull() {
+		criteria = append(criteria, fmt.Sprintf("location %q", config.Location.ValueString()))
+	}
+
+	return common.SelectSingle(subnets, func(s *swagger.VpcSubnet) bool {
+		return (config.ID.IsNull() || s.Id == config.ID.ValueString()) &&
+			(config.Name.IsNull() || s.Name == config.Name.ValueString()) &&
+			(config.Location.IsNull() || s.Location == config.Location.ValueString())
+	}, "VPC subnet", strings.Join(criteria, " and "))
+}
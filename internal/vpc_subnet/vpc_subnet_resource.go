@@ -4,22 +4,33 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/netip"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	swagger "github.com/crusoecloud/client-go/swagger/v1"
 	"github.com/crusoecloud/terraform-provider-crusoe/internal/common"
 )
 
+var (
+	_ resource.Resource                = &vpcSubnetResource{}
+	_ resource.ResourceWithImportState = &vpcSubnetResource{}
+	_ resource.ResourceWithModifyPlan  = &vpcSubnetResource{}
+)
+
 type vpcSubnetResource struct {
 	client *common.CrusoeClient
 }
@@ -29,9 +40,11 @@ type vpcSubnetResourceModel struct {
 	ProjectID         types.String `tfsdk:"project_id"`
 	Name              types.String `tfsdk:"name"`
 	CIDR              types.String `tfsdk:"cidr"`
+	CIDRPrefixLength  types.Int64  `tfsdk:"cidr_prefix_length"`
 	Location          types.String `tfsdk:"location"`
 	Network           types.String `tfsdk:"network"`
 	NATGatewayEnabled types.Bool   `tfsdk:"nat_gateway_enabled"`
+	ManageNATGateway  types.Bool   `tfsdk:"manage_nat_gateway"`
 	NATGateways       types.List   `tfsdk:"nat_gateways"`
 }
 
@@ -86,9 +99,20 @@ func (r *vpcSubnetResource) Schema(ctx context.Context, req resource.SchemaReque
 				},
 			},
 			"cidr": schema.StringAttribute{
-				Required:      true,
-				Description:   apiDescCIDR,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}, // cannot be updated in place
+				Optional:    true,
+				Computed:    true,
+				Description: apiDescCIDR + " " + providerDescCIDR,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(), // keep an allocated block across updates
+					stringplanmodifier.RequiresReplace(),    // cannot be updated in place
+				},
+				Validators: []validator.String{stringvalidator.ExactlyOneOf(path.MatchRoot("cidr_prefix_length"))},
+			},
+			"cidr_prefix_length": schema.Int64Attribute{
+				Optional:      true,
+				Description:   providerDescCIDRPrefixLength,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()}, // cannot be updated in place
+				Validators:    []validator.Int64{int64validator.Between(1, 32)},
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
@@ -117,6 +141,12 @@ func (r *vpcSubnetResource) Schema(ctx context.Context, req resource.SchemaReque
 				Default:             booldefault.StaticBool(false),
 				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
 			},
+			"manage_nat_gateway": schema.BoolAttribute{
+				MarkdownDescription: providerDescManageNATGateway,
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
 			"nat_gateways": schema.ListNestedAttribute{
 				MarkdownDescription: apiDescNATGateways + " " + common.DevelopmentMessage,
 				Computed:            true,
@@ -143,6 +173,85 @@ func (r *vpcSubnetResource) Schema(ctx context.Context, req resource.SchemaReque
 	}
 }
 
+// ModifyPlan checks a new subnet's CIDR against its network before anything is created: an
+// explicit CIDR must be inside the network and not overlap other subnets, and a prefix length
+// must leave a free block. The block itself is allocated on create, so subnets planned together
+// don't all pick the same one.
+//
+//nolint:gocritic // Implements Terraform defined interface
+func (r *vpcSubnetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		planReplacementCIDR(ctx, req, resp)
+
+		return
+	}
+	if r.client == nil {
+		return
+	}
+
+	var plan vpcSubnetResourceModel
+	if err := common.GetResourceModel(ctx, req.Plan, &plan, &resp.Diagnostics); err != nil {
+		return
+	}
+
+	if plan.Network.IsUnknown() || plan.CIDR.IsUnknown() && plan.CIDRPrefixLength.IsUnknown() {
+		return
+	}
+
+	var cidr netip.Prefix
+	if !plan.CIDR.IsUnknown() && !plan.CIDR.IsNull() {
+		var err error
+		cidr, err = netip.ParsePrefix(plan.CIDR.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("cidr"), "Invalid subnet CIDR",
+				fmt.Sprintf("%q is not a valid CIDR block: %s", plan.CIDR.ValueString(), err))
+
+			return
+		}
+	}
+
+	projectID := common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString())
+	network, siblings, err := networkAddressSpace(ctx, r.client.APIClient, projectID, plan.Network.ValueString(), "")
+	if err != nil {
+		resp.Diagnostics.AddWarning("Failed to check subnet CIDR",
+			fmt.Sprintf("The subnet's CIDR could not be checked against its network, so it will only be checked by the API: %s", err))
+
+		return
+	}
+
+	if cidr.IsValid() {
+		if err := checkSubnetCIDR(cidr, network, siblings); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("cidr"), "Invalid subnet CIDR", err.Error())
+		}
+
+		return
+	}
+
+	if !plan.CIDRPrefixLength.IsUnknown() && !plan.CIDRPrefixLength.IsNull() {
+		if _, err := allocateSubnetCIDR(network, siblings, int(plan.CIDRPrefixLength.ValueInt64())); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("cidr_prefix_length"), "Failed to allocate subnet CIDR", err.Error())
+		}
+	}
+}
+
+// planReplacementCIDR leaves cidr unknown when the subnet is replaced and cidr isn't configured.
+// UseStateForUnknown would otherwise plan the old block, and Create only allocates a block for an
+// unknown cidr, so the new subnet would get the old block whatever cidr_prefix_length says.
+func planReplacementCIDR(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if len(resp.RequiresReplace) == 0 {
+		return
+	}
+
+	var cidr types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("cidr"), &cidr)...)
+	if cidr.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("cidr"), types.StringUnknown())...)
+	}
+}
+
 func (r *vpcSubnetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resourceID, projectID, errMsg := common.ParseResourceIdentifiers(req, r.client, "vpc_subnet_id")
 	if errMsg != "" {
@@ -164,12 +273,35 @@ func (r *vpcSubnetResource) Create(ctx context.Context, req resource.CreateReque
 
 	projectID := common.GetProjectIDOrFallback(r.client, plan.ProjectID.ValueString())
 
+	cidr := plan.CIDR.ValueString()
+	if plan.CIDR.IsUnknown() || plan.CIDR.IsNull() {
+		// Hold the lock until the subnet exists, so the next allocation sees it.
+		cidrAllocationMu.Lock()
+		defer cidrAllocationMu.Unlock()
+
+		network, siblings, err := networkAddressSpace(ctx, r.client.APIClient, projectID, plan.Network.ValueString(), "")
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to allocate VPC Subnet CIDR",
+				fmt.Sprintf("There was an error reading the VPC network's address space: %s", err))
+
+			return
+		}
+
+		allocated, err := allocateSubnetCIDR(network, siblings, int(plan.CIDRPrefixLength.ValueInt64()))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to allocate VPC Subnet CIDR", err.Error())
+
+			return
+		}
+		cidr = allocated.String()
+	}
+
 	dataResp, httpResp, err := r.client.APIClient.VPCSubnetsApi.CreateVPCSubnet(ctx, swagger.VpcSubnetPostRequest{
 		Name:              plan.Name.ValueString(),
-		Cidr:              plan.CIDR.ValueString(),
+		Cidr:              cidr,
 		Location:          plan.Location.ValueString(),
 		VpcNetworkId:      plan.Network.ValueString(),
-		NatGatewayEnabled: plan.NATGatewayEnabled.ValueBool(),
+		NatGatewayEnabled: plan.ManageNATGateway.ValueBool() && plan.NATGatewayEnabled.ValueBool(),
 	}, projectID)
 	if httpResp != nil {
 		defer httpResp.Body.Close()
@@ -237,7 +369,7 @@ func (r *vpcSubnetResource) Update(ctx context.Context, req resource.UpdateReque
 	patchReq := swagger.VpcSubnetPatchRequest{
 		Name: plan.Name.ValueString(),
 	}
-	if !plan.NATGatewayEnabled.IsUnknown() && !plan.NATGatewayEnabled.IsNull() {
+	if plan.ManageNATGateway.ValueBool() && !plan.NATGatewayEnabled.IsUnknown() && !plan.NATGatewayEnabled.IsNull() {
 		switch plan.NATGatewayEnabled.ValueBool() {
 		case true:
 			patchReq.NatGatewayAction = "enable"
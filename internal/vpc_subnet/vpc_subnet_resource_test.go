@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -104,3 +105,101 @@ func TestVPCSubnetImportState(t *testing.T) {
 		}
 	})
 }
+
+// vpcSubnetTestValue builds a raw value for the subnet schema from model.
+func vpcSubnetTestValue(ctx context.Context, t *testing.T, s schema.Schema, model *vpcSubnetResourceModel) tftypes.Value {
+	t.Helper()
+
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		t.Fatalf("failed to build subnet value: %v", diags)
+	}
+
+	return state.Raw
+}
+
+func TestVPCSubnetModifyPlanReplacementCIDR(t *testing.T) {
+	ctx := context.Background()
+	r := &vpcSubnetResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	s := schemaResp.Schema
+
+	subnet := func(cidr types.String, prefixLength types.Int64) *vpcSubnetResourceModel {
+		return &vpcSubnetResourceModel{
+			ID:                types.StringValue("subnet-1"),
+			ProjectID:         types.StringValue("proj-1"),
+			Name:              types.StringValue("subnet"),
+			CIDR:              cidr,
+			CIDRPrefixLength:  prefixLength,
+			Location:          types.StringValue("us-east1-a"),
+			Network:           types.StringValue("net-1"),
+			NATGatewayEnabled: types.BoolValue(false),
+			ManageNATGateway:  types.BoolValue(false),
+			NATGateways:       types.ListNull(vpcSubnetNatGatewaySchema),
+		}
+	}
+	oldCIDR := types.StringValue("10.0.0.0/16")
+
+	tests := []struct {
+		name            string
+		state           *vpcSubnetResourceModel
+		config          *vpcSubnetResourceModel
+		requiresReplace path.Paths
+		wantUnknown     bool
+	}{
+		{
+			name:            "prefix length changed",
+			state:           subnet(oldCIDR, types.Int64Value(16)),
+			config:          subnet(types.StringNull(), types.Int64Value(24)),
+			requiresReplace: path.Paths{path.Root("cidr_prefix_length")},
+			wantUnknown:     true,
+		},
+		{
+			name:            "switched from cidr to prefix length",
+			state:           subnet(oldCIDR, types.Int64Null()),
+			config:          subnet(types.StringNull(), types.Int64Value(24)),
+			requiresReplace: path.Paths{path.Root("cidr_prefix_length")},
+			wantUnknown:     true,
+		},
+		{
+			name:            "explicit cidr on replacement",
+			state:           subnet(oldCIDR, types.Int64Null()),
+			config:          subnet(oldCIDR, types.Int64Null()),
+			requiresReplace: path.Paths{path.Root("location")},
+			wantUnknown:     false,
+		},
+		{
+			name:        "in-place update",
+			state:       subnet(oldCIDR, types.Int64Value(16)),
+			config:      subnet(types.StringNull(), types.Int64Value(16)),
+			wantUnknown: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// UseStateForUnknown has already planned the cidr from state.
+			plan := *tt.config
+			plan.CIDR = tt.state.CIDR
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: vpcSubnetTestValue(ctx, t, s, tt.config)},
+				State:  tfsdk.State{Schema: s, Raw: vpcSubnetTestValue(ctx, t, s, tt.state)},
+				Plan:   tfsdk.Plan{Schema: s, Raw: vpcSubnetTestValue(ctx, t, s, &plan)},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan, RequiresReplace: tt.requiresReplace}
+
+			r.ModifyPlan(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var got types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("cidr"), &got)...)
+			if got.IsUnknown() != tt.wantUnknown {
+				t.Errorf("cidr = %s, want unknown = %v", got, tt.wantUnknown)
+			}
+		})
+	}
+}